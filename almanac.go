@@ -0,0 +1,154 @@
+package gocalendar
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	// 建除十二神
+	dayOfficersNameArray = [12]string{"建", "除", "满", "平", "定", "执", "破", "危", "成", "收", "开", "闭"}
+
+	// 二十八宿,角宿为0
+	lunarMansionsNameArray = [28]string{
+		"角木蛟", "亢金龙", "氐土貉", "房日兔", "心月狐", "尾火虎", "箕水豹",
+		"斗木獬", "牛金牛", "女土蝠", "虚日鼠", "危月燕", "室火猪", "壁水貐",
+		"奎木狼", "娄金狗", "胃土雉", "昴日鸡", "毕月乌", "觜火猴", "参水猿",
+		"井木犴", "鬼金羊", "柳土獐", "星日马", "张月鹿", "翼火蛇", "轸水蚓",
+	}
+
+	// 煞方,索引为地支:申子辰煞南,巳酉丑煞东,寅午戌煞北,亥卯未煞西
+	shaDirectionNameArray = [12]string{"南", "东", "北", "西", "南", "东", "北", "西", "南", "东", "北", "西"}
+
+	// 彭祖百忌,天干
+	pengZuStemsArray = [10]string{
+		"甲不开仓财物耗散", "乙不栽植千株不长", "丙不修灶必见灾殃", "丁不剃头头必生疮", "戊不受田田主不祥",
+		"己不破券二比并亡", "庚不经络织机虚张", "辛不合酱主人不尝", "壬不泱水更难提防", "癸不词讼理弱敌强",
+	}
+
+	// 彭祖百忌,地支
+	pengZuBranchesArray = [12]string{
+		"子不问卜自惹祸殃", "丑不冠带主不还乡", "寅不祭祀神鬼不尝", "卯不穿井水泉不香", "辰不哭泣必主重丧", "巳不远行财物伏藏",
+		"午不苫盖屋主更张", "未不服药毒气入肠", "申不安床鬼祟入房", "酉不会客醉坐颠狂", "戌不吃犬作怪上床", "亥不嫁娶不利新郎",
+	}
+
+	// 默认宜忌规则
+	defaultAlmanacRules = mustParseAlmanacRules(almanacRuleData)
+)
+
+// 二十八宿的起算点,儒略日数(JDN)加上该值除以28的余数为二十八宿索引(2019年1月17日为角宿)
+const cLunarMansionOffset = 11
+
+// type AlmanacItem struct 黄历
+type AlmanacItem struct {
+	OfficerIndex int      `json:"oi"`      // 建除十二神索引,建为0
+	Officer      string   `json:"officer"` // 建除十二神
+	MansionIndex int      `json:"mi"`      // 二十八宿索引,角宿为0
+	Mansion      string   `json:"mansion"` // 二十八宿
+	Clash        *GZItem  `json:"clash"`   // 冲的干支
+	ClashAnimal  string   `json:"animal"`  // 冲的生肖
+	Sha          string   `json:"sha"`     // 煞方
+	PengZu       []string `json:"pengzu"`  // 彭祖百忌,天干和地支各一条
+	Yi           []string `json:"yi"`      // 宜
+	Ji           []string `json:"ji"`      // 忌
+}
+
+// type almanacRule struct 宜忌规则
+type almanacRule struct {
+	Yi []string `json:"yi"` // 宜
+	Ji []string `json:"ji"` // 忌
+}
+
+// type AlmanacRules struct 黄历宜忌规则表
+//
+// 数据为JSON格式,以建除十二神为索引,如:
+//
+//	{"建": {"yi": ["出行", "上任"], "ji": ["动土", "开仓"]}, ...}
+type AlmanacRules struct {
+	rules [12]almanacRule
+}
+
+// ParseAlmanacRules 解析宜忌规则数据
+//
+// 规则中没有的建除十二神宜忌为空
+func ParseAlmanacRules(data []byte) (*AlmanacRules, error) {
+	var m map[string]almanacRule
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	ar := new(AlmanacRules)
+	for k, v := range m {
+		i := -1
+		for j, name := range dayOfficersNameArray {
+			if name == k {
+				i = j
+				break
+			}
+		}
+		if i < 0 {
+			return nil, errors.New("宜忌规则索引不是建除十二神:" + k)
+		}
+		ar.rules[i] = v
+	}
+
+	return ar, nil
+}
+
+// mustParseAlmanacRules 解析内置的宜忌规则数据,出错则panic
+func mustParseAlmanacRules(data string) *AlmanacRules {
+	ar, err := ParseAlmanacRules([]byte(data))
+	if err != nil {
+		panic("gocalendar: 内置黄历宜忌规则数据错误: " + err.Error())
+	}
+	return ar
+}
+
+// DefaultAlmanacRules 默认宜忌规则
+func DefaultAlmanacRules() *AlmanacRules {
+	return defaultAlmanacRules
+}
+
+// (*Calendar) almanacRules 当前使用的宜忌规则
+func (c *Calendar) almanacRules() *AlmanacRules {
+	if c.config.AlmanacRules != nil {
+		return c.config.AlmanacRules
+	}
+	return DefaultAlmanacRules()
+}
+
+// (*Calendar) Almanac t所在日期的黄历
+//
+// 建除十二神由日支与月支(以节为月的分界)推算,二十八宿按日序每28日一循环
+func (c *Calendar) Almanac(t time.Time) *AlmanacItem {
+	year, month, day := t.In(c.loc).Date()
+
+	// 用当日正午取干支,避免早晚子时的影响
+	gz := c.ChineseSexagenaryCycle(time.Date(year, month, day, 12, 0, 0, 0, c.loc))
+	dayGZ := gz.Day
+
+	ai := new(AlmanacItem)
+
+	// 日支与月支相同为建,依次为除满平定执破危成收开闭
+	ai.OfficerIndex = (dayGZ.EBI - gz.Month.EBI + 12) % 12
+	ai.Officer = dayOfficersNameArray[ai.OfficerIndex]
+
+	jdn := int(JulianDay(float64(year), float64(month), float64(day)) + 0.5)
+	ai.MansionIndex = (jdn + cLunarMansionOffset) % 28
+	ai.Mansion = lunarMansionsNameArray[ai.MansionIndex]
+
+	// 天克地冲,如甲子日冲戊午
+	l := c.locale()
+	ai.Clash = l.gzItem((dayGZ.HSI+4)%10, (dayGZ.EBI+6)%12)
+	ai.ClashAnimal = c.animalName(l, ai.Clash.EBI)
+	ai.Sha = shaDirectionNameArray[dayGZ.EBI]
+
+	ai.PengZu = []string{pengZuStemsArray[dayGZ.HSI], pengZuBranchesArray[dayGZ.EBI]}
+
+	rule := c.almanacRules().rules[ai.OfficerIndex]
+	ai.Yi = append(ai.Yi, rule.Yi...)
+	ai.Ji = append(ai.Ji, rule.Ji...)
+
+	return ai
+}
@@ -0,0 +1,53 @@
+package gocalendar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalendar_Almanac(t *testing.T) {
+	c := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai"})
+
+	// 2021-05-06 辛丑年癸巳月甲寅日
+	ai := c.Almanac(time.Date(2021, 5, 6, 23, 50, 0, 0, c.loc))
+	if ai.Officer == "收" && ai.Mansion == "角木蛟" && ai.Clash.HSN+ai.Clash.EBN == "戊申" && ai.ClashAnimal == "猴" && ai.Sha == "北" {
+		t.Log("passed")
+	} else {
+		t.Error(ai.Officer, ai.Mansion, ai.Clash, ai.ClashAnimal, ai.Sha)
+	}
+
+	if ai.PengZu[0] == "甲不开仓财物耗散" && ai.PengZu[1] == "寅不祭祀神鬼不尝" && len(ai.Yi) > 0 && ai.Ji[0] == "放债" {
+		t.Log("passed")
+	} else {
+		t.Error(ai.PengZu, ai.Yi, ai.Ji)
+	}
+
+	// 交节之日与前一日建除相同(2021-05-05立夏)
+	a4 := c.Almanac(time.Date(2021, 5, 4, 0, 0, 0, 0, c.loc))
+	a5 := c.Almanac(time.Date(2021, 5, 5, 0, 0, 0, 0, c.loc))
+	if a4.Officer == a5.Officer && a5.OfficerIndex+1 == ai.OfficerIndex {
+		t.Log("passed")
+	} else {
+		t.Error(a4.Officer, a5.Officer, ai.Officer)
+	}
+}
+
+func TestParseAlmanacRules(t *testing.T) {
+	ar, err := ParseAlmanacRules([]byte(`{"收": {"yi": ["结网"], "ji": ["出海"]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai", Almanac: true, Grid: GridDay, AlmanacRules: ar})
+	items := c.GenerateWithDate(2021, 5, 6)
+	ai := items[0].Almanac
+	if ai != nil && len(ai.Yi) == 1 && ai.Yi[0] == "结网" && ai.Ji[0] == "出海" {
+		t.Log("passed")
+	} else {
+		t.Error(ai)
+	}
+
+	if _, err := ParseAlmanacRules([]byte(`{"甲": {"yi": ["结网"]}}`)); err == nil {
+		t.Error("甲不是建除十二神")
+	}
+}
@@ -0,0 +1,19 @@
+package gocalendar
+
+// 内置的黄历宜忌规则
+//
+// 以建除十二神为索引,数据格式见 AlmanacRules
+const almanacRuleData = `{
+  "建": {"yi": ["出行", "上任", "会友", "上书", "见工"], "ji": ["动土", "开仓", "嫁娶", "纳采"]},
+  "除": {"yi": ["除服", "疗病", "出行", "拆卸", "入宅"], "ji": ["求官", "上任", "开张", "搬家", "探病"]},
+  "满": {"yi": ["祈福", "祭祀", "结亲", "开市", "交易"], "ji": ["服药", "求医", "栽种", "动土", "迁移"]},
+  "平": {"yi": ["祭祀", "修填", "涂泥", "余事勿取"], "ji": ["移徙", "入宅", "嫁娶", "开市", "安葬"]},
+  "定": {"yi": ["交易", "立券", "会友", "签约", "纳畜"], "ji": ["种植", "置业", "卖屋", "搬家", "出行"]},
+  "执": {"yi": ["造屋", "装修", "嫁娶", "收购", "立契"], "ji": ["开市", "交易", "搬家", "远行"]},
+  "破": {"yi": ["治病", "破土", "拆卸", "求医"], "ji": ["嫁娶", "签约", "交易", "出行", "搬家"]},
+  "危": {"yi": ["祭祀", "祈福", "安床", "拆卸", "破土"], "ji": ["登山", "乘船", "出行", "嫁娶", "造葬", "迁徙"]},
+  "成": {"yi": ["嫁娶", "开市", "修造", "动土", "安床", "破土", "安葬", "搬迁", "交易", "求财", "出行", "立契", "竖柱", "栽种", "牧养"], "ji": ["诉讼"]},
+  "收": {"yi": ["祈福", "求嗣", "赴任", "嫁娶", "安床", "修造", "动土", "求学", "开市", "交易", "买卖", "立契"], "ji": ["放债", "破土", "安葬"]},
+  "开": {"yi": ["祭祀", "祈福", "入学", "上任", "修造", "动土", "开市", "安床", "交易", "出行", "竖柱"], "ji": ["放债", "诉讼", "安葬"]},
+  "闭": {"yi": ["祭祀", "祈福", "筑堤", "埋池", "埋穴", "造葬", "填补", "修屋"], "ji": ["开市", "出行", "求医", "手术", "嫁娶"]}
+}`
@@ -1,7 +1,7 @@
 package gocalendar
 
 import (
-	"errors"
+	"fmt"
 	"math"
 )
 
@@ -15,40 +15,31 @@ const (
 
 // deltaTDays 地球自转速度调整值Delta T(以∆T表示)
 //
-// 地球时和UTC的时差 单位:天(days)
-func deltaTDays(year,month float64) float64 {
+// 地球时和UTC的时差 单位:天(days),错误同deltaTSeconds
+func deltaTDays(year,month float64) (float64,error) {
 	dt,err := deltaTSeconds(year,month)
-	if err != nil {
-		return 0
-	}
 
-	return Round(dt / 60.0 / 60.0 / 24.0,16)
+	return Round(dt / 60.0 / 60.0 / 24.0,16), err
 }
 
 // deltaTMinutes 地球自转速度调整值Delta T(以∆T表示)
 //
-// 地球时和UTC的时差 单位:分(minutes)
-func deltaTMinutes(year,month float64) float64 {
+// 地球时和UTC的时差 单位:分(minutes),错误同deltaTSeconds
+func deltaTMinutes(year,month float64) (float64,error) {
 	dt,err := deltaTSeconds(year,month)
-	if err != nil {
-		return 0
-	}
 
-	return Round(dt / 60.0,16)
+	return Round(dt / 60.0,16), err
 }
 
 // deltaTSeconds 地球自转速度调整值Delta T(以∆T表示)
 //
 // 地球时和UTC的时差 单位:秒(seconds)
 // 精确至月份
+// 超出-1999年至3000年时返回ErrOutOfRange,同时返回按长期公式外推的值,不可靠
 func deltaTSeconds(year,month float64) (float64,error) {
 	// 计算方法参考: https://eclipse.gsfc.nasa.gov/SEhelp/deltatpoly2004.html
 	// 此算法在-1999年到3000年之间有效
 
-	if year < -1999 || year > 3000 {
-		return 0,errors.New("计算DeltaT值限-1999年至3000年之间有效")
-	}
-
 	y := year + (month - 0.5) / 12
 
 	var dt float64
@@ -110,6 +101,10 @@ func deltaTSeconds(year,month float64) (float64,error) {
 		dt += c
 	}
 
+	if year < -1999 || year > 3000 {
+		return dt,fmt.Errorf("%w: 计算DeltaT值限-1999年至3000年之间有效", ErrOutOfRange)
+	}
+
 	return dt, nil
 }
 
@@ -343,11 +338,12 @@ func meanSolarTermsJd(year float64) [26]float64 {
 
 // adjustedSolarTermsJd 获取指定年以春分开始的节气
 //
-// 经过摄动值和deltaT调整后的jd
-func adjustedSolarTermsJd(year float64,start, end int) [26]float64 {
+// 经过摄动值和deltaT调整后的jd,deltaT超出有效范围时返回其错误
+func adjustedSolarTermsJd(year float64,start, end int) ([26]float64, error) {
 	mst := meanSolarTermsJd(year)
 
 	var jqs [26]float64
+	var dtErr error
 
 	for i, jd := range mst {
 		if i < start {
@@ -362,16 +358,19 @@ func adjustedSolarTermsJd(year float64,start, end int) [26]float64 {
 
 		// 修正dynamical time to Universal time
 		month := math.Floor((float64(i)+1)/2) + 3
-		dtd := deltaTDays(year, month) // delta T(天)
+		dtd, err := deltaTDays(year, month) // delta T(天)
+		if err != nil && dtErr == nil {
+			dtErr = err
+		}
 
 		jqs[i] = Round(jd+pert-dtd, 10) // 加上摄动调整值ptb,减去对应的Delta T值(分钟转换为日)
 	}
 
-	return jqs
+	return jqs, dtErr
 }
 
 // lastYearSolarTerms 取出上一年从冬至开始的6个节气
-func lastYearSolarTerms(year float64) [26]float64 {
+func lastYearSolarTerms(year float64) ([26]float64, error) {
 	return adjustedSolarTermsJd(year-1,18,23)
 }
 
@@ -1,14 +1,15 @@
 package gocalendar
 
 import (
+	"errors"
 	"testing"
 )
 
 
 func TestDeltaTDays(t *testing.T) {
-	dtd := deltaTDays(2021,12)
+	dtd, err := deltaTDays(2021,12)
 	// 0.0008406386097956
-	if Round(dtd,10) == 0.0008406386 {
+	if err == nil && Round(dtd,10) == 0.0008406386 {
 		t.Log("passed")
 	}else{
 		t.Error(dtd)
@@ -18,9 +19,9 @@ func TestDeltaTDays(t *testing.T) {
 
 
 func TestDeltaTMinutes(t *testing.T) {
-	dtmi := deltaTMinutes(2021,12)
+	dtmi, err := deltaTMinutes(2021,12)
 	// 1.2105195981056707
-	if Round(dtmi,10) == 1.2105195981 {
+	if err == nil && Round(dtmi,10) == 1.2105195981 {
 		t.Log("passed")
 	}else{
 		t.Error(dtmi)
@@ -70,7 +71,7 @@ func TestTrueNewMoon(t *testing.T){
 }
 
 func TestAdjustedSolarTermsJd(t *testing.T){
-	jqs := adjustedSolarTermsJd(2021,0,25)
+	jqs, _ := adjustedSolarTermsJd(2021,0,25)
 
 	if Round(jqs[0],10) == 2459293.9010286564 && Round(jqs[1],10) == 2459309.0658356417 && jqs[2] == 2459324.356054907 && Round(jqs[24],10) == 2459659.1481248834 && Round(jqs[25],10) == 2459674.3054912435 {
 		t.Log("passed")
@@ -80,7 +81,7 @@ func TestAdjustedSolarTermsJd(t *testing.T){
 }
 
 func TestLastYearSolarTerms(t *testing.T){
-	ljqs := lastYearSolarTerms(2021)
+	ljqs, _ := lastYearSolarTerms(2021)
 	if ljqs[0] == 0 && ljqs[17] == 0 && ljqs[24] == 0 && Round(ljqs[18],10) == 2459204.9184778044 && Round(ljqs[23],10) == 2459278.8707997804 {
 		t.Log("passed")
 	}else{
@@ -88,3 +89,19 @@ func TestLastYearSolarTerms(t *testing.T){
 	}
 }
 
+
+// Delta T超出-1999年至3000年时返回错误和外推值,不再返回0
+func TestDeltaTOutOfRange(t *testing.T) {
+	dts, err := deltaTSeconds(3100, 6)
+	if errors.Is(err, ErrOutOfRange) && dts > 0 {
+		t.Log("passed")
+	} else {
+		t.Error(dts, err)
+	}
+
+	if _, err := adjustedSolarTermsJd(3100, 0, 25); errors.Is(err, ErrOutOfRange) {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,212 @@
+package gocalendar
+
+import (
+	"math"
+	"time"
+)
+
+// Sex 性别,用于排大运
+type Sex int
+
+const (
+	SexFemale Sex = iota // 女
+	SexMale              // 男
+)
+
+// 大运的步数,每步十年
+const cLuckPillarCount = 10
+
+// 日柱天干的十神位置显示为日主,与十神一样是八字术语,不随Locale翻译
+const cDayMasterName = "日主"
+
+var (
+	// 十神名称,索引为 五行生克关系*2+(阴阳不同为1)
+	tenGodsNameArray = [10]string{"比肩", "劫财", "食神", "伤官", "偏财", "正财", "七杀", "正官", "偏印", "正印"}
+
+	// 地支五行,索引为地支
+	earthlyBranchesElementArray = [12]int{4, 2, 0, 0, 2, 1, 1, 2, 3, 3, 2, 4}
+
+	// 地支藏干,索引为地支,第一个为本气
+	hiddenStemsArray = [12][]int{{9}, {5, 9, 7}, {0, 2, 4}, {1}, {4, 1, 9}, {2, 4, 6}, {3, 5}, {5, 3, 1}, {6, 8, 4}, {7}, {4, 7, 3}, {8, 0}}
+
+	// 纳音,甲子乙丑为一组,依次30组
+	naYinNameArray = [30]string{
+		"海中金", "炉中火", "大林木", "路旁土", "剑锋金", "山头火",
+		"涧下水", "城头土", "白蜡金", "杨柳木", "泉中水", "屋上土",
+		"霹雳火", "松柏木", "长流水", "砂中金", "山下火", "平地木",
+		"壁上土", "金箔金", "覆灯火", "天河水", "大驿土", "钗钏金",
+		"桑柘木", "大溪水", "沙中土", "天上火", "石榴木", "大海水",
+	}
+)
+
+// type HiddenStem struct 地支藏干
+type HiddenStem struct {
+	HSI    int    `json:"hsi"`    // 天干索引
+	HSN    string `json:"hsn"`    // 天干名称
+	TenGod string `json:"tengod"` // 相对日主的十神
+}
+
+// type BaziPillar struct 八字的一柱
+type BaziPillar struct {
+	GZ          *GZItem       `json:"gz"`     // 干支
+	StemTenGod  string        `json:"stg"`    // 天干相对日主的十神,日柱为"日主"
+	HiddenStems []*HiddenStem `json:"hidden"` // 地支藏干
+	NaYin       string        `json:"nayin"`  // 纳音
+}
+
+// type LuckPillar struct 大运
+type LuckPillar struct {
+	GZ        *GZItem    `json:"gz"`     // 干支
+	TenGod    string     `json:"tengod"` // 天干相对日主的十神
+	NaYin     string     `json:"nayin"`  // 纳音
+	StartAge  float64    `json:"age"`    // 起运周岁
+	StartTime *time.Time `json:"start"`  // 起运时间
+}
+
+// type BaziChart struct 八字命盘
+type BaziChart struct {
+	Time         *time.Time    `json:"time"`    // 出生时间
+	Sex          Sex           `json:"sex"`     // 性别
+	Year         *BaziPillar   `json:"year"`    // 年柱
+	Month        *BaziPillar   `json:"month"`   // 月柱
+	Day          *BaziPillar   `json:"day"`     // 日柱
+	Hour         *BaziPillar   `json:"hour"`    // 时柱
+	FiveElements [5]int        `json:"wx"`      // 四柱天干地支(不含藏干)的五行数量,依次为木火土金水
+	Forward      bool          `json:"forward"` // 大运是否顺排
+	LuckPillars  []*LuckPillar `json:"luck"`    // 大运
+}
+
+// gz60 天干地支对应的六十甲子序号,甲子为0
+func gz60(hsi, ebi int) int {
+	return (6*hsi - 5*ebi + 60) % 60
+}
+
+// (*Locale) gz60Item 用六十甲子序号生成该语言的干支
+func (l *Locale) gz60Item(n int) *GZItem {
+	n = (n%60 + 60) % 60
+	return l.gzItem(n%10, n%12)
+}
+
+// NaYin 干支的纳音
+func NaYin(hsi, ebi int) string {
+	if hsi < 0 || hsi > 9 || ebi < 0 || ebi > 11 || hsi%2 != ebi%2 {
+		return ""
+	}
+	return naYinNameArray[gz60(hsi, ebi)/2]
+}
+
+// TenGod 天干hsi相对日主(日干)dayMaster的十神
+func TenGod(dayMaster, hsi int) string {
+	if dayMaster < 0 || dayMaster > 9 || hsi < 0 || hsi > 9 {
+		return ""
+	}
+
+	// 五行生克关系:0同我,1我生,2我克,3克我,4生我
+	rel := (hsi/2 - dayMaster/2 + 5) % 5
+
+	return tenGodsNameArray[rel*2+(hsi+dayMaster)%2]
+}
+
+// newBaziPillar 用干支生成八字的一柱
+func newBaziPillar(l *Locale, gzi *GZItem, dayMaster int) *BaziPillar {
+	bp := &BaziPillar{
+		GZ:         gzi,
+		StemTenGod: TenGod(dayMaster, gzi.HSI),
+		NaYin:      NaYin(gzi.HSI, gzi.EBI),
+	}
+
+	for _, hsi := range hiddenStemsArray[gzi.EBI] {
+		bp.HiddenStems = append(bp.HiddenStems, &HiddenStem{
+			HSI:    hsi,
+			HSN:    l.HeavenlyStems[hsi],
+			TenGod: TenGod(dayMaster, hsi),
+		})
+	}
+
+	return bp
+}
+
+// (*Calendar) BaziChart 出生时间t的八字命盘
+//
+// 四柱由 ChineseSexagenaryCycle 计算,早晚子时和真太阳时按日历的配置处理。
+// 大运阳年生男、阴年生女顺排,否则逆排;起运时间按出生时刻到下一个(顺排)或上一个(逆排)节的时间计算,三天折合一年
+func (c *Calendar) BaziChart(t time.Time, sex Sex) *BaziChart {
+	t = t.In(c.loc)
+	gz := c.ChineseSexagenaryCycle(t)
+	dayMaster := gz.Day.HSI
+	l := c.locale()
+
+	bc := &BaziChart{
+		Time:  &t,
+		Sex:   sex,
+		Year:  newBaziPillar(l, gz.Year, dayMaster),
+		Month: newBaziPillar(l, gz.Month, dayMaster),
+		Day:   newBaziPillar(l, gz.Day, dayMaster),
+		Hour:  newBaziPillar(l, gz.Hour, dayMaster),
+	}
+	bc.Day.StemTenGod = cDayMasterName
+
+	for _, gzi := range []*GZItem{gz.Year, gz.Month, gz.Day, gz.Hour} {
+		bc.FiveElements[gzi.HSI/2]++
+		bc.FiveElements[earthlyBranchesElementArray[gzi.EBI]]++
+	}
+
+	// 阳年男、阴年女顺排
+	bc.Forward = (gz.Year.HSI%2 == 0) == (sex == SexMale)
+
+	// 出生时刻与相邻的节相距的天数
+	days := c.daysToAdjacentJie(t, bc.Forward)
+
+	// 三天为一年,一天为四个月,一个时辰为十天
+	age := days / 3
+	years := math.Floor(age)
+	months := math.Floor((age - years) * 12)
+	mdays := math.Round(((age-years)*12 - months) * 30)
+	start := t.AddDate(int(years), int(months), int(mdays))
+
+	step := 1
+	if !bc.Forward {
+		step = -1
+	}
+	mgz := gz60(gz.Month.HSI, gz.Month.EBI)
+
+	for i := 0; i < cLuckPillarCount; i++ {
+		gzi := l.gz60Item(mgz + step*(i+1))
+		st := start.AddDate(10*i, 0, 0)
+		bc.LuckPillars = append(bc.LuckPillars, &LuckPillar{
+			GZ:        gzi,
+			TenGod:    TenGod(dayMaster, gzi.HSI),
+			NaYin:     NaYin(gzi.HSI, gzi.EBI),
+			StartAge:  Round(age+float64(10*i), 2),
+			StartTime: &st,
+		})
+	}
+
+	return bc
+}
+
+// (*Calendar) daysToAdjacentJie t到下一个(forward为true)或上一个节的天数
+func (c *Calendar) daysToAdjacentJie(t time.Time, forward bool) float64 {
+	jd := timeToJd(t)
+	year := t.Year()
+
+	// pureJieSinceSpring(year)从year年1月的小寒开始,前一年的数据覆盖year年1月小寒之前
+	var jies []float64
+	for _, y := range []int{year - 1, year} {
+		jss, _ := c.pureJieSinceSpring(y)
+		jies = append(jies, jss[:12]...)
+	}
+	jss, _ := c.pureJieSinceSpring(year + 1)
+	jies = append(jies, jss[:2]...)
+
+	for i, v := range jies {
+		if v > jd {
+			if forward {
+				return v - jd
+			}
+			return jd - jies[i-1]
+		}
+	}
+
+	return 0
+}
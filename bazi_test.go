@@ -0,0 +1,73 @@
+package gocalendar
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCalendar_BaziChart(t *testing.T) {
+	c := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai"})
+	bc := c.BaziChart(time.Date(1990, 5, 15, 10, 30, 0, 0, c.loc), SexMale)
+
+	// 庚午 辛巳 庚辰 辛巳
+	pillars := []*BaziPillar{bc.Year, bc.Month, bc.Day, bc.Hour}
+	want := []string{"庚午", "辛巳", "庚辰", "辛巳"}
+	for i, p := range pillars {
+		if p.GZ.HSN+p.GZ.EBN != want[i] {
+			t.Error(i, p.GZ)
+		}
+	}
+
+	if bc.Year.StemTenGod == "比肩" && bc.Month.StemTenGod == "劫财" && bc.Day.StemTenGod == "日主" {
+		t.Log("passed")
+	} else {
+		t.Error(bc.Year.StemTenGod, bc.Month.StemTenGod, bc.Day.StemTenGod)
+	}
+
+	// 午藏丁己
+	hs := bc.Year.HiddenStems
+	if len(hs) == 2 && hs[0].HSN == "丁" && hs[0].TenGod == "正官" && hs[1].HSN == "己" && hs[1].TenGod == "正印" {
+		t.Log("passed")
+	} else {
+		t.Error(hs[0], hs[1])
+	}
+
+	if bc.Year.NaYin == "路旁土" && bc.Day.NaYin == "白蜡金" {
+		t.Log("passed")
+	} else {
+		t.Error(bc.Year.NaYin, bc.Day.NaYin)
+	}
+
+	if bc.FiveElements == [5]int{0, 3, 1, 4, 0} {
+		t.Log("passed")
+	} else {
+		t.Error(bc.FiveElements)
+	}
+
+	// 阳年男顺排,距芒种(1990-06-06)约21.8天,约7.3岁起运
+	lp := bc.LuckPillars
+	if bc.Forward && len(lp) == cLuckPillarCount && lp[0].GZ.HSN+lp[0].GZ.EBN == "壬午" && lp[1].GZ.HSN+lp[1].GZ.EBN == "癸未" &&
+		math.Abs(lp[0].StartAge-7.27) < 0.05 && lp[0].StartTime.Year() == 1997 && lp[1].StartTime.Year() == 2007 {
+		t.Log("passed")
+	} else {
+		t.Error(bc.Forward, lp[0].GZ, lp[0].StartAge, lp[0].StartTime)
+	}
+
+	// 阳年女逆排,距立夏(1990-05-06)约9.3天
+	bc = c.BaziChart(time.Date(1990, 5, 15, 10, 30, 0, 0, c.loc), SexFemale)
+	lp = bc.LuckPillars
+	if !bc.Forward && lp[0].GZ.HSN+lp[0].GZ.EBN == "庚辰" && lp[0].StartAge > 3 && lp[0].StartAge < 3.2 {
+		t.Log("passed")
+	} else {
+		t.Error(bc.Forward, lp[0].GZ, lp[0].StartAge)
+	}
+}
+
+func TestNaYin(t *testing.T) {
+	if NaYin(0, 0) == "海中金" && NaYin(9, 11) == "大海水" && NaYin(0, 1) == "" {
+		t.Log("passed")
+	} else {
+		t.Error(NaYin(0, 0), NaYin(9, 11))
+	}
+}
@@ -0,0 +1,145 @@
+package gocalendar
+
+import (
+	"container/list"
+	"sync"
+)
+
+// 默认缓存的条目数,每个公历年约用6条
+const DefaultAstroCacheSize = 4096
+
+// cacheKind 缓存数据的种类
+type cacheKind uint8
+
+const (
+	cacheSolarTerms     cacheKind = iota // 一整年的节气 []*SolarTermItem
+	cachePureJie                         // 立春点开始的16个节 [16]float64
+	cacheQi                              // 冬至开始的16个中气 [16]float64
+	cacheTrueNewMoon                     // 连续20个朔望月 [20]float64
+	cacheLunarMonthCode                  // 农历月份代码 [15]float64
+	cacheLunarMonthDays                  // 农历月份天数 [15]int
+	cacheHijriMonthStart                 // 伊斯兰历月首的儒略日数 int,year为月序数
+	cachePersianNewYear                  // 波斯历岁首的儒略日数 int,year为波斯历年
+)
+
+// type cacheKey struct 缓存索引
+type cacheKey struct {
+	kind    cacheKind
+	year    int
+	variant string // 结果还与时区、语言等有关时用于区分,否则为空
+}
+
+// type cacheEntry struct 缓存条目
+type cacheEntry struct {
+	key   cacheKey
+	value interface{}
+}
+
+// type CacheStats struct 缓存的统计数据
+type CacheStats struct {
+	Hits      uint64 `json:"hits"`      // 命中次数
+	Misses    uint64 `json:"misses"`    // 未命中次数
+	Evictions uint64 `json:"evictions"` // 因超出容量被淘汰的条目数
+	Len       int    `json:"len"`       // 当前条目数
+	Capacity  int    `json:"capacity"`  // 容量
+}
+
+// type AstroCache struct 节气、朔望月等天文计算结果的缓存
+//
+// 按最近最少使用(LRU)淘汰,可在多个goroutine和多个Calendar之间共享
+type AstroCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[cacheKey]*list.Element
+
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+// 进程内共享的默认缓存
+var defaultAstroCache = NewAstroCache(DefaultAstroCacheSize)
+
+// NewAstroCache 新建容量为capacity条的缓存,capacity小于1时使用DefaultAstroCacheSize
+func NewAstroCache(capacity int) *AstroCache {
+	if capacity < 1 {
+		capacity = DefaultAstroCacheSize
+	}
+	return &AstroCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[cacheKey]*list.Element),
+	}
+}
+
+// DefaultAstroCache 进程内共享的默认缓存
+func DefaultAstroCache() *AstroCache {
+	return defaultAstroCache
+}
+
+// (*AstroCache) get 读缓存
+func (ac *AstroCache) get(k cacheKey) (interface{}, bool) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	if e, ok := ac.items[k]; ok {
+		ac.ll.MoveToFront(e)
+		ac.hits++
+		return e.Value.(*cacheEntry).value, true
+	}
+	ac.misses++
+	return nil, false
+}
+
+// (*AstroCache) set 写缓存,超出容量时淘汰最久未使用的条目
+func (ac *AstroCache) set(k cacheKey, v interface{}) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	if e, ok := ac.items[k]; ok {
+		ac.ll.MoveToFront(e)
+		e.Value.(*cacheEntry).value = v
+		return
+	}
+
+	ac.items[k] = ac.ll.PushFront(&cacheEntry{key: k, value: v})
+	for ac.ll.Len() > ac.capacity {
+		e := ac.ll.Back()
+		ac.ll.Remove(e)
+		delete(ac.items, e.Value.(*cacheEntry).key)
+		ac.evictions++
+	}
+}
+
+// (*AstroCache) Stats 缓存的统计数据
+func (ac *AstroCache) Stats() CacheStats {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	return CacheStats{
+		Hits:      ac.hits,
+		Misses:    ac.misses,
+		Evictions: ac.evictions,
+		Len:       ac.ll.Len(),
+		Capacity:  ac.capacity,
+	}
+}
+
+// (*AstroCache) Purge 清空缓存和统计数据
+func (ac *AstroCache) Purge() {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	ac.ll.Init()
+	ac.items = make(map[cacheKey]*list.Element)
+	ac.hits, ac.misses, ac.evictions = 0, 0, 0
+}
+
+// (*Calendar) astroCache 当前使用的天文计算缓存
+func (c *Calendar) astroCache() *AstroCache {
+	if c.config.AstroCache != nil {
+		return c.config.AstroCache
+	}
+	return DefaultAstroCache()
+}
@@ -0,0 +1,90 @@
+package gocalendar
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestAstroCache(t *testing.T) {
+	ac := NewAstroCache(2)
+	ac.set(cacheKey{kind: cacheQi, year: 2020}, 1)
+	ac.set(cacheKey{kind: cacheQi, year: 2021}, 2)
+	ac.get(cacheKey{kind: cacheQi, year: 2020}) // 2021最久未使用
+	ac.set(cacheKey{kind: cacheQi, year: 2022}, 3)
+
+	_, ok2020 := ac.get(cacheKey{kind: cacheQi, year: 2020})
+	_, ok2021 := ac.get(cacheKey{kind: cacheQi, year: 2021})
+	st := ac.Stats()
+	if ok2020 && !ok2021 && st.Hits == 2 && st.Misses == 1 && st.Evictions == 1 && st.Len == 2 && st.Capacity == 2 {
+		t.Log("passed")
+	} else {
+		t.Error(ok2020, ok2021, st)
+	}
+
+	ac.Purge()
+	if st := ac.Stats(); st.Len == 0 && st.Hits == 0 {
+		t.Log("passed")
+	} else {
+		t.Error(st)
+	}
+}
+
+func TestCalendar_AstroCacheShared(t *testing.T) {
+	ac := NewAstroCache(0)
+	cfg := CalendarConfig{TimeZoneName: "Asia/Shanghai", AstroCache: ac}
+
+	ld := NewCalendar(cfg).GregorianToLunar(2021, 5, 6)
+	misses := ac.Stats().Misses
+
+	// 新的Calendar和克隆都使用同一个缓存,同一年不再重新计算
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c := NewCalendar(cfg).Clone()
+			if c.GregorianToLunar(2021, 5, 6).Day != ld.Day {
+				t.Error("农历日期不一致")
+			}
+		}()
+	}
+	wg.Wait()
+
+	st := ac.Stats()
+	if st.Misses == misses && st.Hits > 0 {
+		t.Log("passed")
+	} else {
+		t.Error(misses, st)
+	}
+
+	// 不同时区的节气分开缓存
+	c1 := NewCalendar(cfg)
+	c2 := NewCalendar(CalendarConfig{TimeZoneName: "UTC", AstroCache: ac})
+	if c1.SolarTerms(2021)[0].Time.Location() != c2.SolarTerms(2021)[0].Time.Location() {
+		t.Log("passed")
+	} else {
+		t.Error(c1.SolarTerms(2021)[0].Time, c2.SolarTerms(2021)[0].Time)
+	}
+}
+
+func TestCalendar_AstroCacheSolarTermsCopy(t *testing.T) {
+	ac := NewAstroCache(0)
+	cfg := CalendarConfig{Grid: GridDay, TimeZoneName: "Asia/Shanghai", SolarTerms: true, AstroCache: ac}
+
+	// 修改返回的节气不影响缓存中的和其它Calendar
+	a := NewCalendar(cfg)
+	sts := a.SolarTerms(2021)
+	sts[0].Name = "x"
+	*sts[0].Time = sts[0].Time.AddDate(1, 0, 0)
+	items := a.GenerateWithDate(2021, 6, 21)
+	items[0].SolarTerm.Name = "y"
+
+	b := NewCalendar(cfg)
+	bsts := b.SolarTerms(2021)
+	items = b.GenerateWithDate(2021, 6, 21)
+	if bsts[0].Name == "冬至" && bsts[0].Time.Year() == 2020 && items[0].SolarTerm.Name == "夏至" {
+		t.Log("passed")
+	} else {
+		t.Error(bsts[0], items[0].SolarTerm)
+	}
+}
@@ -8,10 +8,8 @@
 package gocalendar
 
 import (
-	"errors"
 	"fmt"
 	"math"
-	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -26,12 +24,14 @@ type SolarTermItem struct {
 	Index int        `json:"index"` // 节气索引
 	Name  string     `json:"name"`  // 节气名称
 	Time  *time.Time `json:"time"`  // 定节气时间
+
+	locale *Locale // 显示用的语言
 }
 
 // type yearSolarTermTemp struct 节气缓存年表
 type yearSolarTermTemp struct {
-	data map[int][]*SolarTermItem
-	mu   sync.RWMutex
+	cache   *AstroCache
+	variant string // 节气的时间和名称与时区和语言有关
 }
 
 // type StarSignItem struct 星座单元
@@ -49,19 +49,37 @@ type FestivalItem struct {
 // type yearFestivalTemp struct 节日缓存年表
 type yearFestivalTemp struct {
 	data map[int]map[string][]string
+	ver  uint64 // 缓存对应的节日表修改次数
 	mu   sync.RWMutex
 }
 
 // type CalendarItem struct 日历单元
 type CalendarItem struct {
-	Time         *time.Time     `json:"time"`     // 格里高历(公历)时间
-	IsAccidental int            `json:"isam"`     // 0为本月日期,-1为上一个月的日期,1为下一个月的日期,
-	IsToday      int            `json:"istoday"`  // 是否是今天,0不是,1是
-	Festival     *FestivalItem  `json:"festival"` // 公历节日
-	SolarTerm    *SolarTermItem `json:"st"`       // 节气
-	GZ           *GZ            `json:"gz"`       // 干支
-	LunarDate    *LunarDate     `json:"ld"`       // 农历
-	StarSign     *StarSignItem  `json:"ss"`       // 星座
+	Time         *time.Time     `json:"time"`          // 格里高历(公历)时间
+	IsAccidental int            `json:"isam"`          // 0为本月日期,-1为上一个月的日期,1为下一个月的日期,
+	IsToday      int            `json:"istoday"`       // 是否是今天,0不是,1是
+	Festival     *FestivalItem  `json:"festival"`      // 公历节日
+	SolarTerm    *SolarTermItem `json:"st"`            // 节气
+	GZ           *GZ            `json:"gz"`            // 干支
+	LunarDate    *LunarDate     `json:"ld"`            // 农历
+	StarSign     *StarSignItem  `json:"ss"`            // 星座
+	DayType      DayType        `json:"dt,omitempty"`  // 日期类型(工作日、周末、法定节假日、调休上班)
+	DayTypeName  string         `json:"dtn,omitempty"` // 日期类型名称,按Locale显示
+	MoonPhase    *MoonPhaseItem `json:"moon"`          // 月相
+	Sun          *SunItem       `json:"sun"`           // 日出日落
+	Almanac      *AlmanacItem   `json:"almanac"`       // 黄历
+	HijriDate    *HijriDate     `json:"hijri"`         // 伊斯兰历
+	HebrewDate   *HebrewDate    `json:"hebrew"`        // 希伯来历
+	PersianDate  *PersianDate   `json:"persian"`       // 波斯历
+
+	locale *Locale // 显示用的语言
+}
+
+// type MonthGrid struct 年日历表中的一个月
+type MonthGrid struct {
+	Year  int             `json:"year"`  // 年
+	Month int             `json:"month"` // 月
+	Items []*CalendarItem `json:"items"` // 该月的月日历表
 }
 
 // Calendar的一些临时数据
@@ -74,19 +92,36 @@ type CalendarTempData struct {
 	lMD *lunarMonthDays15Temp // 对应农历某年的月份对应天数表
 	lFD *yearFestivalTemp     // 对应农历某年的节日表
 	gFD *yearFestivalTemp     // 对应公历某年的节日表
+	hFD *yearFestivalTemp     // 对应伊斯兰历某年的节日表
+	jFD *yearFestivalTemp     // 对应希伯来历某年的节日表
+	pFD *yearFestivalTemp     // 对应波斯历某年的节日表
 }
 
 // 初始Calendar的临时数据
-func newCalendarTempData() *CalendarTempData {
+//
+// 节气和农历的天文计算结果存放在c.astroCache()中,可在多个Calendar之间共享;节日缓存属于该Calendar
+func (c *Calendar) newCalendarTempData() *CalendarTempData {
+	ac := c.astroCache()
+
+	// 农历的变体和历史农历的计算结果不同,缓存时要区分
+	lv, hv := strconv.Itoa(c.config.LunarVariant), ""
+	if c.config.HistoricalLunar {
+		hv = "historical"
+		lv += "|" + hv
+	}
+
 	return &CalendarTempData{
-		st:  new(yearSolarTermTemp),
-		jSS: new(pureJieQi16Temp),
-		qSS: new(pureJieQi16Temp),
-		tNM: new(trueNewMoon20Temp),
-		lMC: new(lunarMonthCode15Temp),
-		lMD: new(lunarMonthDays15Temp),
+		st:  &yearSolarTermTemp{cache: ac, variant: c.loc.String() + "|" + c.locale().Name},
+		jSS: &pureJieQi16Temp{cache: ac, kind: cachePureJie},
+		qSS: &pureJieQi16Temp{cache: ac, kind: cacheQi, variant: hv},
+		tNM: &trueNewMoon20Temp{cache: ac, kind: cacheTrueNewMoon, variant: hv},
+		lMC: &lunarMonthCode15Temp{cache: ac, kind: cacheLunarMonthCode, variant: lv},
+		lMD: &lunarMonthDays15Temp{cache: ac, kind: cacheLunarMonthDays, variant: lv},
 		lFD: new(yearFestivalTemp),
 		gFD: new(yearFestivalTemp),
+		hFD: new(yearFestivalTemp),
+		jFD: new(yearFestivalTemp),
+		pFD: new(yearFestivalTemp),
 	}
 }
 
@@ -152,8 +187,13 @@ func DefaultCalendar() *Calendar {
 // NewCalendar 日历设置
 func NewCalendar(cfg CalendarConfig) *Calendar {
 
-	cfg.Grid = int(math.Mod(math.Abs(float64(cfg.Grid)), 3))
+	cfg.Grid = int(math.Mod(math.Abs(float64(cfg.Grid)), 6))
 	cfg.FirstWeek = int(math.Mod(math.Abs(float64(cfg.FirstWeek)), 7))
+	cfg.Latitude = math.Max(-90, math.Min(90, cfg.Latitude))
+	cfg.Longitude = math.Remainder(cfg.Longitude, 360)
+	if cfg.LunarVariant < ChineseLunar || cfg.LunarVariant > VietnameseLunar {
+		cfg.LunarVariant = ChineseLunar
+	}
 
 	// 默认时区
 	var loc *time.Location
@@ -171,16 +211,23 @@ func NewCalendar(cfg CalendarConfig) *Calendar {
 		}
 	}
 
+	// Locale的有效性
+	if lookupLocale(cfg.Locale) == nil {
+		cfg.Locale = DefaultLocaleName
+	}
+
 	// 默认 rawTime
 	rawTime := time.Now().In(loc)
 
-	return &Calendar{
-		Items:    nil,
-		config:   &cfg,
-		loc:      loc,
-		rawTime:  &rawTime,
-		tempData: newCalendarTempData(),
+	c := &Calendar{
+		Items:   nil,
+		config:  &cfg,
+		loc:     loc,
+		rawTime: &rawTime,
 	}
+	c.tempData = c.newCalendarTempData()
+
+	return c
 }
 
 // (*Calendar) SetRawTime 设置rawTime
@@ -217,9 +264,9 @@ func (c *Calendar) setRawTime(t time.Time) *Calendar {
 	// 重新设置rawTime后，请除原相关数据
 	c.Items = nil
 
-	// 清临时数据
+	// 清临时数据,共享的天文计算缓存不受影响
 	if rawYear != t.Year() {
-		c.tempData = newCalendarTempData()
+		c.tempData = c.newCalendarTempData()
 	}
 
 	return c
@@ -288,6 +335,18 @@ func (c *Calendar) Generate() []*CalendarItem {
 	case GridMonth:
 		// 月日历表
 		result = c.monthCalendar()
+	case GridLunarMonth:
+		// 农历月日历表
+		result = c.lunarMonthCalendar()
+	case GridSolarTermMonth:
+		// 节气月日历表
+		result = c.solarTermMonthCalendar()
+	case GridYear:
+		// 年日历表,12个月的月日历表依次排列
+		for _, mg := range c.YearCalendar() {
+			result = append(result, mg.Items...)
+		}
+		c.Items = result
 	default:
 		// 月日历表
 		result = c.monthCalendar()
@@ -337,8 +396,16 @@ func (c *Calendar) weekCalendar() []*CalendarItem {
 
 // (*Calendar) monthGregorianCalendar 一个月的日历表
 func (c *Calendar) monthCalendar() []*CalendarItem {
+	itemsSlice := c.monthItems(c.GetRawTime())
 
-	rawTime := c.GetRawTime()
+	// 附值给Calendar.Items
+	c.Items = itemsSlice
+
+	return itemsSlice
+}
+
+// (*Calendar) monthItems t所在月份的月日历表,共6周42天
+func (c *Calendar) monthItems(rawTime time.Time) []*CalendarItem {
 
 	// 本月第一天time
 	t := BeginningOfMonth(rawTime)
@@ -364,17 +431,120 @@ func (c *Calendar) monthCalendar() []*CalendarItem {
 	}
 	wg.Wait()
 
-	var itemsSlice []*CalendarItem
-	itemsSlice = itemsArray[:]
+	return itemsArray[:]
+}
+
+// (*Calendar) YearCalendar rawTime所在年份的年日历表
+//
+// 返回1至12月的月日历表,每月与GridMonth的月日历表相同(按FirstWeek排列,共42天,IsAccidental相对于该月)
+func (c *Calendar) YearCalendar() []*MonthGrid {
+	year := c.GetRawTime().Year()
+
+	// 先算好相关年份的节气,各月共用缓存,避免并发时重复计算
+	if c.config.SolarTerms {
+		for y := year - 1; y <= year+1; y++ {
+			c.solarTerms(y)
+		}
+	}
+
+	grids := make([]*MonthGrid, 12)
+	for m := 1; m <= 12; m++ {
+		t := time.Date(year, time.Month(m), 1, 0, 0, 0, 0, c.loc)
+		grids[m-1] = &MonthGrid{
+			Year:  year,
+			Month: m,
+			Items: c.monthItems(t),
+		}
+	}
+
+	return grids
+}
+
+// (*Calendar) lunarMonthCalendar rawTime所在农历月的日历表,共6周42天
+//
+// 日历表从该农历月初一所在的周开始(按FirstWeek排列),IsAccidental相对于该农历月:
+// 0是该月日期,-1为上一月日期,1为下一月日期
+func (c *Calendar) lunarMonthCalendar() []*CalendarItem {
+	first, days := c.lunarMonthSpan(c.GetRawTime())
 
 	// 附值给Calendar.Items
-	c.Items = itemsSlice
+	c.Items = c.spanItems(first, first.AddDate(0, 0, days-1))
 
-	// copy一个返回
-	var resultItems = make([]*CalendarItem, len(itemsSlice))
-	copy(resultItems, itemsSlice)
+	return c.Items
+}
 
-	return itemsSlice
+// (*Calendar) spanItems 包含first至last的日历表,共6周42天
+//
+// 日历表从first所在的周开始(按FirstWeek排列),IsAccidental相对于first至last:
+// 0是其中的日期,-1为之前的日期,1为之后的日期
+func (c *Calendar) spanItems(first, last time.Time) []*CalendarItem {
+	// 日历表首日
+	_, firstDayTime := c.firstDay(first)
+
+	// item
+	var itemsArray [42]*CalendarItem
+	var wg = sync.WaitGroup{}
+	wg.Add(cap(itemsArray))
+	for i := 0; i < cap(itemsArray); i++ {
+		go func(i int) {
+			defer wg.Done()
+
+			gt := firstDayTime.AddDate(0, 0, i)
+			item := c.createItem(gt, gt.Year(), int(gt.Month()))
+			if gt.Before(first) {
+				item.IsAccidental = -1
+			} else if gt.After(last) {
+				item.IsAccidental = 1
+			}
+			itemsArray[i] = item
+		}(i)
+	}
+	wg.Wait()
+
+	return itemsArray[:]
+}
+
+// (*Calendar) lunarMonthSpan t所在农历月的初一(c.loc时区的零点)和该月天数
+func (c *Calendar) lunarMonthSpan(t time.Time) (time.Time, int) {
+	year, month, day := t.Date()
+	ld := c.GregorianToLunar(year, int(month), day)
+
+	first := time.Date(year, month, day-ld.Day+1, 0, 0, 0, 0, c.loc)
+	days, err := c.LunarMonthDays(ld.Year, ld.Month, ld.LeapStr != "")
+	if err != nil {
+		days = 30
+	}
+
+	return first, days
+}
+
+// (*Calendar) LunarMonthCalendar 农历某月的日历表,isLeap为是否闰月
+//
+// rawTime设为该月初一,IsAccidental相对于该农历月。
+// 该年没有指定的闰月时返回ErrNoLeapMonth
+func (c *Calendar) LunarMonthCalendar(lunarYear, lunarMonth int, isLeap bool) ([]*CalendarItem, error) {
+	t, err := c.LunarToGregorian(lunarYear, lunarMonth, 1, isLeap)
+	if err != nil {
+		return nil, err
+	}
+
+	c.setRawTime(t)
+	return c.lunarMonthCalendar(), nil
+}
+
+// (*Calendar) NextLunarMonth 下一个农历月,有闰月时依次经过闰月
+func (c *Calendar) NextLunarMonth() []*CalendarItem {
+	first, days := c.lunarMonthSpan(c.GetRawTime())
+	c.setRawTime(first.AddDate(0, 0, days))
+	return c.lunarMonthCalendar()
+}
+
+// (*Calendar) PreviousLunarMonth 上一个农历月,有闰月时依次经过闰月
+func (c *Calendar) PreviousLunarMonth() []*CalendarItem {
+	first, _ := c.lunarMonthSpan(c.GetRawTime())
+	prev, _ := c.lunarMonthSpan(first.AddDate(0, 0, -1))
+	c.setRawTime(prev)
+	return c.lunarMonthCalendar()
 }
 
 // (*Calendar) firstDate 计算t与c.config.FirstWeek相关几日，并同时返回首日time
@@ -409,9 +579,10 @@ func (c *Calendar) createItem(t time.Time, currentYear, currentMonth int) *Calen
 	item := new(CalendarItem)
 
 	item.Time = &t
+	item.locale = c.locale()
 
 	var wg = sync.WaitGroup{}
-	wg.Add(7) // 在修改时要注意这里定义goroutine次数
+	wg.Add(14) // 在修改时要注意这里定义goroutine次数
 
 	// 是否非本月的日期,0是本月日期,-1为上一月日期,1为下一月日期
 	go func() {
@@ -461,12 +632,12 @@ func (c *Calendar) createItem(t time.Time, currentYear, currentMonth int) *Calen
 
 		if c.config.SolarTerms {
 			// sts := c.SolarTerms(c.rawTime.Year())
-			sts := c.SolarTerms(year)
+			sts, _ := c.solarTerms(year)
 
 			stkStr := "2006-1-2"
 			for _, stv := range sts {
 				if t.Format(stkStr) == stv.Time.Format(stkStr) {
-					item.SolarTerm = stv
+					item.SolarTerm = stv.clone()
 					break
 				}
 			}
@@ -488,7 +659,7 @@ func (c *Calendar) createItem(t time.Time, currentYear, currentMonth int) *Calen
 		defer wg.Done()
 
 		if c.config.Lunar {
-			ld := c.gregorianToLunar(t, true)
+			ld, _ := c.gregorianToLunar(t, true)
 			item.LunarDate = &ld
 		}
 	}()
@@ -498,8 +669,75 @@ func (c *Calendar) createItem(t time.Time, currentYear, currentMonth int) *Calen
 		defer wg.Done()
 
 		if c.config.StarSign {
-			ssi, ss, _ := StarSign(month, day)
-			item.StarSign = &StarSignItem{Index: ssi, Name: ss}
+			ssi, _, _ := StarSign(month, day)
+			item.StarSign = &StarSignItem{Index: ssi, Name: item.locale.StarSigns[ssi]}
+		}
+	}()
+
+	// 日期类型
+	go func() {
+		defer wg.Done()
+
+		if c.config.Holiday {
+			item.DayType = c.holidaySchedule().DayType(t)
+			item.DayTypeName = item.locale.DayType(item.DayType)
+		}
+	}()
+
+	// 月相
+	go func() {
+		defer wg.Done()
+
+		if c.config.MoonPhase {
+			item.MoonPhase = c.moonPhase(t)
+		}
+	}()
+
+	// 日出日落
+	go func() {
+		defer wg.Done()
+
+		if c.config.Sun {
+			item.Sun = c.SunTimes(t)
+		}
+	}()
+
+	// 黄历
+	go func() {
+		defer wg.Done()
+
+		if c.config.Almanac {
+			item.Almanac = c.Almanac(t)
+		}
+	}()
+
+	// 伊斯兰历
+	go func() {
+		defer wg.Done()
+
+		if c.config.Hijri {
+			hd := c.GregorianToHijri(year, month, day)
+			item.HijriDate = &hd
+		}
+	}()
+
+	// 希伯来历
+	go func() {
+		defer wg.Done()
+
+		if c.config.Hebrew {
+			hd := c.GregorianToHebrew(year, month, day)
+			item.HebrewDate = &hd
+		}
+	}()
+
+	// 波斯历
+	go func() {
+		defer wg.Done()
+
+		if c.config.Persian {
+			pd := c.GregorianToPersian(year, month, day)
+			item.PersianDate = &pd
 		}
 	}()
 
@@ -512,11 +750,16 @@ func (c *Calendar) createItem(t time.Time, currentYear, currentMonth int) *Calen
 func (c *Calendar) gregorianFestival(t time.Time) FestivalItem {
 	gregorianYear, gregorianMonth, gregorianDay := t.Date()
 
+	fr := c.festivalRegistry()
+
+	// 节日表修改后清除已缓存的节日
+	c.tempData.gFD.sync(fr.version())
+
 	fds := c.tempData.gFD.getData(gregorianYear)
 
 	if len(fds) == 0 {
 
-		for gfK, gfV := range gregorianFestivalArray {
+		for gfK, gfV := range fr.Festivals(FestivalGregorian) {
 			var err error
 			trueK := "" // 经过处理转换成月日的K
 			m := ""     // 月
@@ -528,7 +771,7 @@ func (c *Calendar) gregorianFestival(t time.Time) FestivalItem {
 
 			// 某月第几周几 索引正则
 			if strings.Index(gfK, "W") > -1 {
-				re := regexp.MustCompile("^([0-9]{1,2})M(?:D)?([1-4])W([0-6])$").FindStringSubmatch(gfK)
+				re := gregorianFestivalWeekRegexp.FindStringSubmatch(gfK)
 				if len(re) != 4 {
 					continue // 索引格式不正确
 				}
@@ -582,7 +825,7 @@ func (c *Calendar) gregorianFestival(t time.Time) FestivalItem {
 				}
 
 			} else {
-				re := regexp.MustCompile("^([0-9]{1,2})M([0-9]{1,2})D$").FindStringSubmatch(gfK)
+				re := gregorianFestivalDayRegexp.FindStringSubmatch(gfK)
 				if len(re) != 3 {
 					continue // 索引格式不正确
 				}
@@ -628,35 +871,40 @@ func (c *Calendar) gregorianFestival(t time.Time) FestivalItem {
 
 	festivalIndex := strconv.Itoa(int(gregorianMonth)) + "M" + strconv.Itoa(gregorianDay) + "D"
 
-	var fi FestivalItem // 该日的节日
-	if fv, ok := fds[festivalIndex]; ok {
-		for _, v := range fv {
-			v = strings.TrimSpace(v)
-			if svs := strings.Split(v, "*"); len(svs) > 1 {
-				fi.Show = append(fi.Show, svs[1])
-			} else {
-				fi.Secondary = append(fi.Secondary, v)
-			}
-		}
-	}
-
-	return fi
+	// 该日的节日
+	return festivalItemFromNames(fds[festivalIndex])
 }
 
 // (*Calendar) SolarTerms 一整年的节气
 //
 // 从上一年的冬至开始到下一年的小寒共26个节气对应的日期时间,
 // 设置c.stMap的值 map[string]*SolarTerm是一个以"年-月-日"为索引的map,
-// 返回[]*SolarTerm是一个有序切片
+// 返回[]*SolarTerm是一个有序切片,是缓存中节气的副本,可以修改
 func (c *Calendar) SolarTerms(year int) []*SolarTermItem {
+	sts, _ := c.solarTerms(year)
+
+	csts := make([]*SolarTermItem, len(sts))
+	for i, stv := range sts {
+		csts[i] = stv.clone()
+	}
+
+	return csts
+}
+
+// (*Calendar) solarTerms 一整年的节气,同SolarTerms
+//
+// 返回的节气在AstroCache中,可能被其它Calendar和goroutine共用,只能读,不能修改。
+// 用到的Delta T超出有效范围时返回其错误,结果不缓存
+func (c *Calendar) solarTerms(year int) ([]*SolarTermItem, error) {
 	sts := c.tempData.st.getData(year)
 	if sts != nil && len(sts) == 26 {
-		return sts
+		return sts, nil
 	}
 
 	ji := -1
+	l := c.locale()
 
-	lastYearAsts := lastYearSolarTerms(float64(year))
+	lastYearAsts, dtErr := lastYearSolarTerms(float64(year))
 
 	for i, v := range lastYearAsts {
 		if v == 0 {
@@ -676,13 +924,17 @@ func (c *Calendar) SolarTerms(year int) []*SolarTermItem {
 
 		stTime := JdToTime(v, c.loc)
 		stItem.Index = (ji + 18) % 24 // 节气名称的索引
-		stItem.Name = solarTermsNameArray[stItem.Index]
+		stItem.Name = l.SolarTerms[stItem.Index]
 		stItem.Time = &stTime
+		stItem.locale = l
 
 		sts = append(sts, stItem)
 	}
 
-	asts := adjustedSolarTermsJd(float64(year), 0, 19)
+	asts, err := adjustedSolarTermsJd(float64(year), 0, 19)
+	if dtErr == nil {
+		dtErr = err
+	}
 	for i, v := range asts {
 
 		if v == 0 {
@@ -699,22 +951,30 @@ func (c *Calendar) SolarTerms(year int) []*SolarTermItem {
 
 		stTime := JdToTime(v, c.loc)
 		stItem.Index = (ji + 18) % 24 // 节气名称的索引
-		stItem.Name = solarTermsNameArray[stItem.Index]
+		stItem.Name = l.SolarTerms[stItem.Index]
 		stItem.Time = &stTime
+		stItem.locale = l
 
 		sts = append(sts, stItem)
 	}
 
+	if dtErr != nil {
+		return sts, dtErr
+	}
+
 	c.tempData.st.setData(year, sts)
 
-	return sts
+	return sts, nil
 }
 
 // StarSign 根据月和日取星座
+//
+// 不区分年份,2月29日视为合法日期
 func StarSign(month, day int) (int, string, error) {
 
-	if month < 1 || month > 12 || day < 1 || day > 31 {
-		return 0, "", errors.New("日期错误！")
+	// 按闰年检查该月的天数
+	if month < 1 || month > 12 || day < 1 || day > GregorianMonthDays(2000, month) {
+		return 0, "", ErrInvalidDate
 	}
 
 	// 星座的起始日期
@@ -730,7 +990,7 @@ func StarSign(month, day int) (int, string, error) {
 
 // (SolarTermItem) String 节气显示
 func (sti SolarTermItem) String() string {
-	return fmt.Sprintf("%s 定%s:%s", sti.Name, sti.Name, sti.Time.Format(time.RFC3339))
+	return fmt.Sprintf(sti.locale.orDefault().SolarTermFormat, sti.Name, sti.Time.Format(time.RFC3339))
 }
 
 // (CalendarItem) String 日历单元显示
@@ -740,8 +1000,9 @@ func (ci CalendarItem) String() string {
 	if ci.IsToday == 1 {
 		todayStr = "*"
 	}
+	l := ci.locale.orDefault()
 	weekIndex := ci.Time.Weekday()
-	weekString := fmt.Sprintf("%s周%s", todayStr, weekNameArray[weekIndex])
+	weekString := todayStr + l.Weekdays[weekIndex]
 
 	festivalString := ""
 	if ci.Festival != nil && ci.Festival.Show != nil {
@@ -756,15 +1017,12 @@ func (ci CalendarItem) String() string {
 			festivalString = " " + strings.Join(ci.LunarDate.Festival.Show, ",")
 		}
 
-		lunarString = fmt.Sprintf(" %d%s%s(%s)年%s%s月%s", ci.LunarDate.Year, ci.LunarDate.YearGZ.HSN, ci.LunarDate.YearGZ.EBN, ci.LunarDate.AnimalName, ci.LunarDate.LeapStr, ci.LunarDate.MonthName, ci.LunarDate.DayName)
+		lunarString = " " + l.lunarDate(*ci.LunarDate)
 	}
 
 	var gzString = ""
 	if ci.GZ != nil {
-		gzStr := ci.GZ.String()
-		subByte := "日"
-		el := strings.Index(gzStr, subByte) + len(subByte)
-		gzString = " " + string([]byte(gzStr)[:el])
+		gzString = " " + fmt.Sprintf(l.GZDateFormat, l.gz(ci.GZ.Year), l.gz(ci.GZ.Month), l.gz(ci.GZ.Day))
 	}
 
 	var solarTermString = ""
@@ -777,27 +1035,15 @@ func (ci CalendarItem) String() string {
 
 // (*yearSolarTermTemp) getData 读节气缓存年表
 func (ystt *yearSolarTermTemp) getData(k int) []*SolarTermItem {
-	ystt.mu.RLock()
-	defer ystt.mu.RUnlock()
-	var rv []*SolarTermItem
-	if ystt.data == nil {
-		ystt.data = make(map[int][]*SolarTermItem)
-	}
-	if v, ok := ystt.data[k]; ok {
-		rv = v
+	if v, ok := ystt.cache.get(cacheKey{cacheSolarTerms, k, ystt.variant}); ok {
+		return v.([]*SolarTermItem)
 	}
-
-	return rv
+	return nil
 }
 
-// (*yearSolarTermTemp) getData 写节气缓存年表
+// (*yearSolarTermTemp) setData 写节气缓存年表
 func (ystt *yearSolarTermTemp) setData(k int, v []*SolarTermItem) {
-	ystt.mu.Lock()
-	defer ystt.mu.Unlock()
-	if ystt.data == nil {
-		ystt.data = make(map[int][]*SolarTermItem)
-	}
-	ystt.data[k] = v
+	ystt.cache.set(cacheKey{cacheSolarTerms, k, ystt.variant}, v)
 }
 
 // (*yearFestivalTemp) getData 读节日缓存年表
@@ -825,3 +1071,13 @@ func (yft *yearFestivalTemp) setData(k int, v map[string][]string) {
 	}
 	yft.data[k] = v
 }
+
+// (*yearFestivalTemp) sync 节日表修改次数与缓存不一致时清空节日缓存年表
+func (yft *yearFestivalTemp) sync(ver uint64) {
+	yft.mu.Lock()
+	defer yft.mu.Unlock()
+	if yft.ver != ver {
+		yft.data = make(map[int]map[string][]string)
+		yft.ver = ver
+	}
+}
@@ -2,6 +2,7 @@ package gocalendar
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -129,7 +130,98 @@ func TestCalendar_monthCalendar(t *testing.T) {
 	}
 
 
-	t.Logf("st len=%d jSS len=%d qSS len=%d tNM len=%d lMC len=%d lMD len=%d lFD len=%d gFD len=%d",len(c.tempData.st.data),len(c.tempData.jSS.data),len(c.tempData.qSS.data),len(c.tempData.tNM.data),len(c.tempData.lMC.data),len(c.tempData.lMD.data),len(c.tempData.lFD.data),len(c.tempData.gFD.data))
+	t.Logf("astro cache %+v lFD len=%d gFD len=%d",c.astroCache().Stats(),len(c.tempData.lFD.data),len(c.tempData.gFD.data))
+}
+
+// 年日历表
+func TestCalendar_YearCalendar(t *testing.T) {
+	beforeTime := time.Now()
+	defer func() {
+		str := time.Since(beforeTime)
+		t.Logf("本次执行用时：%s\n", str)
+	}()
+
+	c := NewCalendar(CalendarConfig{Grid: GridYear, FirstWeek: 1, TimeZoneName: "Asia/Shanghai", SolarTerms: true, Lunar: true, HeavenlyEarthly: true, Holiday: true})
+	c.SetRawTime(2021, 5, 6)
+
+	grids := c.YearCalendar()
+	if len(grids) != 12 {
+		t.Fatalf("YearCalendar len = %d, want 12", len(grids))
+	}
+
+	for i, mg := range grids {
+		if mg.Year != 2021 || mg.Month != i+1 || len(mg.Items) != 42 {
+			t.Errorf("grid %d = %d-%d len %d", i, mg.Year, mg.Month, len(mg.Items))
+			continue
+		}
+		if wd := mg.Items[0].Time.Weekday(); wd != time.Monday {
+			t.Errorf("%d-%d first weekday = %s, want Monday", mg.Year, mg.Month, wd)
+		}
+		for _, item := range mg.Items {
+			want := 0
+			if m := int(item.Time.Month()); item.Time.Year() < mg.Year || (item.Time.Year() == mg.Year && m < mg.Month) {
+				want = -1
+			} else if item.Time.Year() > mg.Year || m > mg.Month {
+				want = 1
+			}
+			if item.IsAccidental != want {
+				t.Errorf("%s IsAccidental = %d, want %d (month %d)", item.Time.Format("2006-01-02"), item.IsAccidental, want, mg.Month)
+			}
+		}
+	}
+
+	// 2021-02-03 立春
+	if st := grids[1].Items[2].SolarTerm; st == nil || st.Name != "立春" {
+		t.Errorf("2021-02-03 SolarTerm = %v, want 立春", st)
+	}
+
+	items := c.Generate()
+	if len(items) != 12*42 || len(c.Items) != 12*42 {
+		t.Errorf("Generate GridYear len = %d, Items len = %d, want %d", len(items), len(c.Items), 12*42)
+	}
+
+	t.Logf("astro cache %+v", c.astroCache().Stats())
+}
+
+// 农历月日历表
+func TestCalendar_LunarMonthCalendar(t *testing.T) {
+	c := NewCalendar(CalendarConfig{Grid: GridLunarMonth, FirstWeek: 0, TimeZoneName: "Asia/Shanghai", Lunar: true})
+
+	// 当月的第一日、天数和是否闰月
+	check := func(items []*CalendarItem, first string, days int, leap bool) {
+		var cur []*CalendarItem
+		for _, item := range items {
+			if item.IsAccidental == 0 {
+				cur = append(cur, item)
+			}
+		}
+		if len(items) != 42 || len(cur) != days || cur[0].Time.Format("2006-01-02") != first ||
+			cur[0].LunarDate.Day != 1 || (cur[0].LunarDate.LeapStr != "") != leap || items[0].Time.Weekday() != time.Sunday {
+			t.Errorf("want %s %d days leap=%v, got %d items %d days from %v", first, days, leap, len(items), len(cur), cur[0].Time)
+			return
+		}
+		t.Log("passed")
+	}
+
+	// 2023年闰二月
+	items, err := c.LunarMonthCalendar(2023, 2, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check(items, "2023-02-20", 30, false)
+	check(c.NextLunarMonth(), "2023-03-22", 29, true)
+	check(c.NextLunarMonth(), "2023-04-20", 29, false)
+	check(c.PreviousLunarMonth(), "2023-03-22", 29, true)
+	check(c.PreviousLunarMonth(), "2023-02-20", 30, false)
+
+	// 跨公历年
+	c.SetRawTime(2022, 1, 15)
+	check(c.Generate(), "2022-01-03", 29, false)
+	check(c.NextLunarMonth(), "2022-02-01", 30, false)
+
+	if _, err := c.LunarMonthCalendar(2021, 2, true); !errors.Is(err, ErrNoLeapMonth) {
+		t.Error(err)
+	}
 }
 
 // 星座
@@ -4,14 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"math"
-	"regexp"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 )
 
-// 中国(东八区)时间相对UTC的偏移量(单位：天days)
+// 中国(东八区)时间相对UTC的偏移量(单位：天days),其它农历的时区见(*Calendar) lunarTimeOffsetDays
 const cChineseTimeOffsetDays float64 = 8 / 24.0
 
 // type GZItem 天干地支单元
@@ -28,6 +26,10 @@ type GZ struct {
 	Month *GZItem `json:"mgz"` // 月天干地支
 	Day   *GZItem `json:"dgz"` // 日天干地支
 	Hour  *GZItem `json:"hgz"` // 时天干地支
+
+	SolarTime *time.Time `json:"stime"` // 计算日柱和时柱所用的真太阳时,未启用真太阳时为nil
+
+	locale *Locale // 显示用的语言
 }
 
 // type LunarDate 农历
@@ -43,37 +45,54 @@ type LunarDate struct {
 	AnimalName    string   `json:"san"`       // 年生肖名称
 	YearGZ        *GZItem  `json:"ygz"`       // 年干支
 	Festival      *FestivalItem `json:"festival"`  // 农历节日
+	Eras          []EraYear `json:"eras"`     // 年号纪年,设置CalendarConfig.EraNames时才有,并存的政权各一个
+
+	locale *Locale // 显示用的语言
 }
 
 // type pureJieQi16Temp struct pureJieSinceSpring和qiSinceWinterSolstice的缓存
 type pureJieQi16Temp struct {
-	data map[int][16]float64
-	mu sync.RWMutex
+	cache *AstroCache
+	kind cacheKind
+	variant string
 }
 
 // type trueNewMoon20Temp struct 20个新月点年表缓存
 type trueNewMoon20Temp struct {
-	data map[int][20]float64
-	mu sync.RWMutex
+	cache *AstroCache
+	kind cacheKind
+	variant string
 }
 
 // type lunarMonthCode15Temp struct农历月名称年表缓存
 type lunarMonthCode15Temp struct {
-	data map[int][15]float64
-	mu sync.RWMutex
+	cache *AstroCache
+	kind cacheKind
+	variant string
 }
 
 // type lunarMonthDays15Temp struct 农历月份对应的天数年表缓存
 type lunarMonthDays15Temp struct {
-	data map[int][15]int
-	mu sync.RWMutex
+	cache *AstroCache
+	kind cacheKind
+	variant string
 }
 
 // (*Calendar) ChineseSexagenaryCycle 日期时间对应的干支
 //
 // 特别提醒:干支推算的日干支存在早晚子时的区别
 // NightZiHour默认为false是不区分早晚子时00:00-02:00为子时，NightZiHour为true时，23:00-24:00 00:00-01:00为子时
+// TrueSolarTime为true时,日柱、时柱和早晚子时按CalendarConfig.Longitude处的真太阳时计算,年柱和月柱仍按t计算,
+// 需设置CalendarConfig.LocationSet,未设置时按t的钟面时间计算
 func (c *Calendar) ChineseSexagenaryCycle(t time.Time) GZ {
+	gzs, _ := c.chineseSexagenaryCycle(t)
+	return gzs
+}
+
+// (*Calendar) chineseSexagenaryCycle 日期时间对应的干支
+//
+// 节气用到的Delta T超出有效范围时返回其错误,设置了TrueSolarTime而未设置LocationSet时返回ErrNoLocation
+func (c *Calendar) chineseSexagenaryCycle(t time.Time) (GZ, error) {
 	year, month, day := t.Date()
 	hour, minute, second := t.Clock()
 
@@ -92,14 +111,14 @@ func (c *Calendar) ChineseSexagenaryCycle(t time.Time) GZ {
 	// 立春点开始的节，年干支以立春开始(本方法为了日历使用，在计算中以立春当天为准，不考虑详细时间)
 	// jss中儒略日是TT时间(这里强制为UTC时间)，是未经时区修改的儒略日
 	// 在与jd比较时，应加上时区时差
-	jss := c.pureJieSinceSpring(year)
+	jss, err := c.pureJieSinceSpring(year)
 
 	// 以立春当天0时作比较，不考虑定立春的时分秒，所以用math.Floor向下取整数部分
 	if math.Floor(jd+0.5) < math.Floor(jss[1] + 0.5 + offsetDays) { // $jss[1]为立春，约在2月5日前后。
 		year-- // 若小于jss[1]则属于前一个节气年
 
 		// 取得自立春开始的节(不包含中气)，该数组长度固定为16
-		jss = c.pureJieSinceSpring(year)
+		jss, err = c.pureJieSinceSpring(year)
 	}
 
 	// 年干支
@@ -127,6 +146,19 @@ func (c *Calendar) ChineseSexagenaryCycle(t time.Time) GZ {
 		EBI: mgz % 12, // 月支
 	}
 
+	// 日柱和时柱使用真太阳时,未设置地理位置时不用
+	if c.config.TrueSolarTime && !c.config.LocationSet && err == nil {
+		err = ErrNoLocation
+	}
+	if c.config.TrueSolarTime && c.config.LocationSet {
+		st := TrueSolarTime(t, c.config.Longitude)
+		gzs.SolarTime = &st
+
+		sYear, sMonth, sDay := st.Date()
+		hour, minute, second = st.Clock()
+		jd = JulianDay(float64(sYear), float64(sMonth), float64(sDay), float64(hour), float64(minute), float64(second))
+	}
+
 	jdn := jd + 0.5                                  // 计算日柱的干支，加0.5是将起始点从正午改为0时开始
 	thes := ((jdn - math.Floor(jdn)) * 86400) + 3600 // 将jd的小数部分化为秒，并加上起始点前移的一小时(3600秒)
 	dayJd := math.Floor(jdn) + thes/86400            // 将秒数化为日数，加回到jd的整数部分
@@ -151,32 +183,32 @@ func (c *Calendar) ChineseSexagenaryCycle(t time.Time) GZ {
 		EBI: hgz % 12, // 时支
 	}
 
-	// 为干支附中文名称
-	gzs.Year.HSN   = heavenlyStemsNameArray[gzs.Year.HSI]
-	gzs.Year.EBN  = earthlyBranchesNameArray[gzs.Year.EBI]
-	gzs.Month.HSN  = heavenlyStemsNameArray[gzs.Month.HSI]
-	gzs.Month.EBN = earthlyBranchesNameArray[gzs.Month.EBI]
-	gzs.Day.HSN    = heavenlyStemsNameArray[gzs.Day.HSI]
-	gzs.Day.EBN   = earthlyBranchesNameArray[gzs.Day.EBI]
-	gzs.Hour.HSN   = heavenlyStemsNameArray[gzs.Hour.HSI]
-	gzs.Hour.EBN  = earthlyBranchesNameArray[gzs.Hour.EBI]
+	// 为干支附名称
+	l := c.locale()
+	gzs.locale = l
+	gzs.Year  = l.gzItem(gzs.Year.HSI, gzs.Year.EBI)
+	gzs.Month = l.gzItem(gzs.Month.HSI, gzs.Month.EBI)
+	gzs.Day   = l.gzItem(gzs.Day.HSI, gzs.Day.EBI)
+	gzs.Hour  = l.gzItem(gzs.Hour.HSI, gzs.Hour.EBI)
 
-	return gzs
+	return gzs, err
 }
 
 
 
 // (*Calendar) pureJieSinceSpring 求出以某年立春点开始的节
-func (c *Calendar) pureJieSinceSpring(year int) [16]float64 {
+//
+// Delta T超出有效范围时返回其错误,结果不缓存
+func (c *Calendar) pureJieSinceSpring(year int) ([16]float64, error) {
 	// jss 16个节的jd数据
 
 	// 如果c.jSS记录了该年的数据，则直接返回
 	jss,err := c.tempData.jSS.getData(year)
 	if err == nil {
-		return jss
+		return jss, nil
 	}
 
-	lastYearAsts := lastYearSolarTerms(float64(year))
+	lastYearAsts, dtErr := lastYearSolarTerms(float64(year))
 
 	ki := -1 // 数组索引
 
@@ -194,7 +226,10 @@ func (c *Calendar) pureJieSinceSpring(year int) [16]float64 {
 		jss[ki] = lastYearAsts[i] // 中国(东八区)时差放在具体计算时调整，此处不做调整
 	}
 
-	asts := adjustedSolarTermsJd(float64(year), 0, 25)
+	asts, err := adjustedSolarTermsJd(float64(year), 0, 25)
+	if dtErr == nil {
+		dtErr = err
+	}
 	for i := 1; i <= 25; i += 2 {
 		// if i%2 == 0 {
 		// 	continue
@@ -208,22 +243,38 @@ func (c *Calendar) pureJieSinceSpring(year int) [16]float64 {
 		jss[ki] = asts[i] // 中国(东八区)时差放在具体计算时调整，此处不做调整
 	}
 
+	if dtErr != nil {
+		return jss, dtErr
+	}
+
 	c.tempData.jSS.setData(year,jss)
 
-	return jss
+	return jss, nil
 }
 
 // (*Calendar) qiSinceWinterSolstice 求出自上一年冬至点为起点的连续中气
-func (c *Calendar) qiSinceWinterSolstice(year int) [16]float64 {
+//
+// Delta T超出有效范围时返回其错误,结果不缓存
+func (c *Calendar) qiSinceWinterSolstice(year int) ([16]float64, error) {
 	// qss 16个中气的jd
 
 	// 如果c.qSS记录了该年的数据，则直接返回
 	qss,err := c.tempData.qSS.getData(year)
 	if err == nil {
-		return qss
+		return qss, nil
+	}
+
+	// 历史农历1645年以前用平气
+	if c.lunarRule(year) != lunarRuleDingQiDingShuo {
+		qss, err = meanQiSinceWinterSolstice(year)
+		if err != nil {
+			return qss, err
+		}
+		c.tempData.qSS.setData(year,qss)
+		return qss, nil
 	}
 
-	lastYearAsts := lastYearSolarTerms(float64(year))
+	lastYearAsts, dtErr := lastYearSolarTerms(float64(year))
 
 	ki := -1 // 数组索引
 
@@ -238,7 +289,10 @@ func (c *Calendar) qiSinceWinterSolstice(year int) [16]float64 {
 		qss[ki] = lastYearAsts[i] // 中国(东八区)时差放在具体计算时调整，此处不做调整
 	}
 
-	asts := adjustedSolarTermsJd(float64(year), 0, 25)
+	asts, err := adjustedSolarTermsJd(float64(year), 0, 25)
+	if dtErr == nil {
+		dtErr = err
+	}
 	for i := 0; i <= 24; i += 2 {
 		if asts[i] == 0 {
 			continue
@@ -249,9 +303,13 @@ func (c *Calendar) qiSinceWinterSolstice(year int) [16]float64 {
 		qss[ki] = asts[i] // 中国(东八区)时差放在具体计算时调整，此处不做调整
 	}
 
+	if dtErr != nil {
+		return qss, dtErr
+	}
+
 	c.tempData.qSS.setData(year,qss)
 
-	return qss
+	return qss, nil
 }
 
 
@@ -263,11 +321,78 @@ func (c *Calendar) qiSinceWinterSolstice(year int) [16]float64 {
 func (c *Calendar) GregorianToLunar(year, month, day int) LunarDate {
 	t := time.Date(year,time.Month(month),day,0,0,0,0,c.loc)
 
-	return c.gregorianToLunar(t,false) // festival:false时不取农历节日
+	ld, _ := c.gregorianToLunar(t,false) // festival:false时不取农历节日
+	return ld
 }
 
 // (*Calendar) gregorianToLunar 公历转农历
-func (c *Calendar) gregorianToLunar(t time.Time,festival bool) LunarDate{
+//
+// 按天文算法计算时,用到的Delta T超出有效范围则返回其错误
+func (c *Calendar) gregorianToLunar(t time.Time,festival bool) (LunarDate, error){
+	year, month, day := t.Date()
+
+	// 农历表覆盖的年份查表,否则按天文算法计算
+	var err error
+	lunarYear, lunarMonth, lunarDay, isLeap, ok := c.tableGregorianToLunar(year, int(month), day)
+	if !ok {
+		lunarYear, lunarMonth, lunarDay, isLeap, err = c.astroGregorianToLunar(t)
+	}
+
+	// 整理年月日农历表示
+	l := c.locale()
+	monthName := l.LunarMonths[lunarMonth - 1]
+	dayName := l.LunarDays[lunarDay - 1]
+	ygz := ((lunarYear+4712+24)%60 + 60) % 60
+	yhsi := ygz % 10
+	yebi := ygz % 12
+	animalIndex := yebi
+	animalName := c.animalName(l, yebi)
+	yearGZ := l.gzItem(yhsi, yebi)
+
+	// 整理闰月相关
+	leapStr := "" //
+	leapMonth := 0 // 闰几月
+	if isLeap {
+		leapStr = l.LunarLeap
+		leapMonth = lunarMonth
+	}
+
+	// 农历节日
+	var lf FestivalItem
+	if festival {
+		lf = c.lunarFestival(lunarYear,lunarMonth,lunarDay,isLeap)
+	}
+
+
+	// 年号纪年
+	var eras []EraYear
+	if c.config.EraNames {
+		eras = erasOf(lunarYear,lunarMonth,lunarDay,isLeap,t)
+	}
+
+	// 返回
+	return LunarDate{
+		Year:          lunarYear,
+		Month:         lunarMonth,
+		Day:           lunarDay,
+		MonthName:     monthName,
+		DayName:       dayName,
+		LeapStr:       leapStr,
+		YearLeapMonth: leapMonth,
+		AnimalIndex:   animalIndex,
+		AnimalName:    animalName,
+		YearGZ:        yearGZ,
+		Festival:      &lf,
+		Eras:          eras,
+		locale:        l,
+	}, err
+}
+
+
+// (*Calendar) astroGregorianToLunar 按天文算法将公历日期转为农历
+//
+// 用到的Delta T超出有效范围时返回其错误
+func (c *Calendar) astroGregorianToLunar(t time.Time) (int, int, int, bool, error) {
 	year, month, day := t.Date()
 	hour, minute, second := t.Clock()
 
@@ -276,25 +401,27 @@ func (c *Calendar) gregorianToLunar(t time.Time,festival bool) LunarDate{
 	prev := 0 // 是否跨年了,跨年了则减一
 	isLeap := false // 是否闰月
 
+	offset := c.lunarTimeOffsetDays()
+
 	// t的儒略日
 	jd := JulianDay(float64(year), float64(month), float64(day), float64(hour), float64(minute), float64(second))
 
 	jdn := jd + 0.5 // 加0.5是将起始点从正午改为0时开始
 
-	nm, lmc := c.zqAndSMandLunarMonthCode(year)
+	nm, lmc, err := c.zqAndSMandLunarMonthCode(year)
 
 	// 如果公历日期的jd小于第一个朔望月新月点，表示农历年份是在公历年份的上一年
-	if math.Floor(jdn) < math.Floor(nm[0] + 0.5 + cChineseTimeOffsetDays) {
+	if math.Floor(jdn) < math.Floor(nm[0] + 0.5 + offset) {
 
 		prev = 1
-		nm, lmc = c.zqAndSMandLunarMonthCode(year-1)
+		nm, lmc, err = c.zqAndSMandLunarMonthCode(year-1)
 
 	}
 
 	// 查询对应的农历月份索引
 	var mi = 0
 	for i := 0; i <= 14; i++ { // 指令中加0.5是为了改为从0时算起而不从正午算起
-		if math.Floor(jdn) >= math.Floor(nm[i]+0.5 + cChineseTimeOffsetDays) && math.Floor(jdn) < math.Floor(nm[i+1]+0.5 + cChineseTimeOffsetDays) {
+		if math.Floor(jdn) >= math.Floor(nm[i]+0.5 + offset) && math.Floor(jdn) < math.Floor(nm[i+1]+0.5 + offset) {
 			mi = i
 			break
 		}
@@ -315,56 +442,11 @@ func (c *Calendar) gregorianToLunar(t time.Time,festival bool) LunarDate{
 	lunarMonth := int(math.Floor(lmc[mi] + 10)) % 12 + 1
 
 	// 农历的日
-	lunarDay := int(math.Floor(jdn) - math.Floor(nm[mi] + 0.5 + cChineseTimeOffsetDays) + 1) // 此处加1是因为每月初一从1开始而非从0开始
+	lunarDay := int(math.Floor(jdn) - math.Floor(nm[mi] + 0.5 + offset) + 1) // 此处加1是因为每月初一从1开始而非从0开始
 
-
-	// 整理年月日农历表示
-	monthName := lunarMonthNameArray[lunarMonth - 1]
-	dayName := DayChinese(lunarDay)
-	ygz := ((lunarYear+4712+24)%60 + 60) % 60
-	yhsi := ygz % 10
-	yebi := ygz % 12
-	animalIndex := yebi
-	animalName := symbolicAnimalsNameArray[yebi]
-	yearGZ := &GZItem{
-		HSI:  yhsi,
-		HSN:  heavenlyStemsNameArray[yhsi],
-		EBI: yebi,
-		EBN: earthlyBranchesNameArray[yebi],
-	}
-
-	// 整理闰月相关
-	leapStr := "" //
-	leapMonth := 0 // 闰几月
-	if isLeap {
-		leapStr = lunarLeapString
-		leapMonth = lunarMonth
-	}
-
-	// 农历节日
-	var lf FestivalItem
-	if festival {
-		lf = c.lunarFestival(lunarYear,lunarMonth,lunarDay,isLeap)
-	}
-
-
-	// 返回
-	return LunarDate{
-		Year:          lunarYear,
-		Month:         lunarMonth,
-		Day:           lunarDay,
-		MonthName:     monthName,
-		DayName:       dayName,
-		LeapStr:       leapStr,
-		YearLeapMonth: leapMonth,
-		AnimalIndex:   animalIndex,
-		AnimalName:    animalName,
-		YearGZ:        yearGZ,
-		Festival:      &lf,
-	}
+	return lunarYear, lunarMonth, lunarDay, isLeap, err
 }
 
-
 // (*Calendar) LunarToGregorian 农历转公历
 //
 // demo:
@@ -396,7 +478,20 @@ func (c *Calendar) lunarToGregorian(ld LunarDate) (time.Time, error){
 		isLeap = true
 	}
 
-	nm, lmc := c.zqAndSMandLunarMonthCode(lunarYear)
+	// 农历表覆盖的年份查表
+	if info, ok := c.lunarTableYear(lunarYear); ok {
+		jdn, err := info.jdn(lunarMonth, lunarDay, isLeap)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return lunarTableTime(jdn, c.loc), nil
+	}
+
+	nm, lmc, err := c.zqAndSMandLunarMonthCode(lunarYear)
+	if err != nil {
+		return time.Time{}, err
+	}
+	offset := c.lunarTimeOffsetDays()
 
 	// 该年闰几月，0无闰月
 	leapMonth := mcLeap(lmc)
@@ -406,7 +501,7 @@ func (c *Calendar) lunarToGregorian(ld LunarDate) (time.Time, error){
 
 	var nofd [15]int
 	for i := 0; i <= 14; i++ {
-		nofd[i] = int(math.Floor(nm[i+1] + 0.5 + cChineseTimeOffsetDays) - math.Floor(nm[i] + 0.5 + cChineseTimeOffsetDays)) // 每月天数,加0.5是因JD以正午起算
+		nofd[i] = int(math.Floor(nm[i+1] + 0.5 + offset) - math.Floor(nm[i] + 0.5 + offset)) // 每月天数,加0.5是因JD以正午起算
 	}
 
 	var jd float64
@@ -414,15 +509,15 @@ func (c *Calendar) lunarToGregorian(ld LunarDate) (time.Time, error){
 	if isLeap { // 闰月
 
 		if leapMonth < 3 { // 而旗标非闰月或非本年闰月,则表示此年不含闰月.leap=0代表无闰月,=1代表闰月为前一年的11月,=2代表闰月为前一年的12月
-			return time.Time{}, errors.New("此年非闰年") // 此年非闰年
+			return time.Time{}, ErrNoLeapMonth // 此年非闰年
 		} else { // 若本年內有闰月
 			if leapMonth != lunarMonth { // 但不为指入的月份
-				return time.Time{}, errors.New("该月非闰月") // 则指定的月份非闰月,此月非闰月
+				return time.Time{}, ErrNoLeapMonth // 则指定的月份非闰月,此月非闰月
 			} else { // 若输入的月份即为闰月
 				if lunarDay <= nofd[lunarMonth] { // 若指定的日期不大于当月的天數
 					jd = nm[lunarMonth] + float64(lunarDay) - 1 // 则将当月之前的JD值加上日期之前的天數
 				} else { // 日期超出范围
-					return time.Time{}, errors.New("日期超出范围")
+					return time.Time{}, ErrDayOutOfMonth
 				}
 			}
 		}
@@ -432,7 +527,7 @@ func (c *Calendar) lunarToGregorian(ld LunarDate) (time.Time, error){
 			if lunarDay <= nofd[lunarMonth-1] { // 若日期不大于当月天数
 				jd = nm[lunarMonth-1] + float64(lunarDay) - 1 // 则将当月之前的JD值加上日期之前的天数
 			} else { // 日期超出范围
-				return time.Time{}, errors.New("日期超出范围")
+				return time.Time{}, ErrDayOutOfMonth
 			}
 		} else { // 若旗标为本年有闰月(包括前一年的11月起之月份) 公式nofd(lunarMonth - (lunarMonth > leapMonth) - 1)的用意为:若指定月大于闰月,则索引用lunarMonth,否则索引用lunarMonth-1
 			k := lunarMonth -1
@@ -442,13 +537,15 @@ func (c *Calendar) lunarToGregorian(ld LunarDate) (time.Time, error){
 			if lunarDay <= nofd[k] { // 若输入的日期不大于当月天数
 				jd = nm[k] + float64(lunarDay) - 1 // 则将当月之前的JD值加上日期之前的天数
 			} else { // 日期超出范围
-				return time.Time{}, errors.New("日期超出范围")
+				return time.Time{}, ErrDayOutOfMonth
 			}
 		}
 	}
 
-	jd = math.Floor(jd) + 0.5
-	return JdToTime(jd, c.loc), nil
+	// 农历日期以农历所用时区(中国为东八区)的日期为准,返回c.loc时区该日的0时
+	jdn := math.Floor(jd + 0.5 + offset)
+	tm := JdToTimeMap(jdn)
+	return time.Date(tm["year"], time.Month(tm["month"]), tm["day"], 0, 0, 0, 0, c.loc), nil
 }
 
 // (*Calendar) LunarMonthDay 农历某个月有多少天
@@ -458,14 +555,23 @@ func (c *Calendar) lunarToGregorian(ld LunarDate) (time.Time, error){
 // @param bool isLeap  是否是闰月
 func (c *Calendar) LunarMonthDays(lunarYear,lunarMonth int, isLeap bool) (int,error) {
 
+	// 农历表覆盖的年份查表
+	if info, ok := c.lunarTableYear(lunarYear); ok {
+		mi, err := info.monthIndex(lunarMonth, isLeap)
+		if err != nil {
+			return 0, err
+		}
+		return info.days[mi], nil
+	}
+
 	var lmc [15]float64
 
 	// 如果c.lMC记录了该年的数据，则直接赋值
 	lMC,err := c.tempData.lMC.getData(lunarYear)
 	if err == nil{
 		lmc = lMC
-	}else{
-		_, lmc = c.zqAndSMandLunarMonthCode(lunarYear)
+	}else if _, lmc, err = c.zqAndSMandLunarMonthCode(lunarYear); err != nil {
+		return 0, err
 	}
 
 	// 闰几月，0无闰月
@@ -474,17 +580,20 @@ func (c *Calendar) LunarMonthDays(lunarYear,lunarMonth int, isLeap bool) (int,er
 	// 11月对应到1,12月对应到2,1月对应到3,2月对应到4,依此类推
 	lunarMonth += 2
 
-	lmd := c.mdList(lunarYear)
+	lmd, err := c.mdList(lunarYear)
+	if err != nil {
+		return 0, err
+	}
 
 	dy := 0 // 当月天数
 
 	if isLeap {
 		if leapMonth < 3 { // 而旗标非闰月或非本年闰月,则表示此年不含闰月.leapMonth=0代表无闰月,=1代表闰月为前一年的11月,=2代表闰月为前一年的12月
-			return 0, errors.New("该年非闰年")
+			return 0, ErrNoLeapMonth
 		}
 		// 若本年內有闰月
 		if leapMonth != lunarMonth { // 但不为指定的月份
-			return 0, errors.New("该月非该年的闰月")
+			return 0, ErrNoLeapMonth
 		} else { // 若指定的月份即为闰月
 			dy = lmd[lunarMonth]
 		}
@@ -506,29 +615,37 @@ func (c *Calendar) LunarMonthDays(lunarYear,lunarMonth int, isLeap bool) (int,er
 
 
 // 农历一年的月份对应天数表
-func (c *Calendar)mdList(lunarYear int) [15]int {
+func (c *Calendar)mdList(lunarYear int) ([15]int, error) {
 	// 如果c.lMD记录了该年的数据，则直接返回
 	lmd,err := c.tempData.lMD.getData(lunarYear)
 	if err == nil {
-		return lmd
+		return lmd, nil
 	}
 
-	nm, _ := c.zqAndSMandLunarMonthCode(lunarYear)
+	nm, _, err := c.zqAndSMandLunarMonthCode(lunarYear)
+	if err != nil {
+		return lmd, err
+	}
+	offset := c.lunarTimeOffsetDays()
 
 	for i := 0; i <= 14; i++ {
-		lmd[i] = int(math.Floor(nm[i+1] + 0.5 + cChineseTimeOffsetDays) - math.Floor(nm[i] + 0.5 + cChineseTimeOffsetDays)) // 每月天数,加0.5是因JD以正午起算
+		lmd[i] = int(math.Floor(nm[i+1] + 0.5 + offset) - math.Floor(nm[i] + 0.5 + offset)) // 每月天数,加0.5是因JD以正午起算
 	}
 
 	c.tempData.lMD.setData(lunarYear,lmd)
 
-	return lmd
+	return lmd, nil
 }
 
 // (*Calendar) LunarLeap 取农历某年的闰月
 //
 // 0表示无闰月
 func (c *Calendar) LunarLeap(lunarYear int) int {
-	_,lmc := c.zqAndSMandLunarMonthCode(lunarYear)
+	if info, ok := c.lunarTableYear(lunarYear); ok {
+		return info.leap
+	}
+
+	_,lmc,_ := c.zqAndSMandLunarMonthCode(lunarYear)
 
 	leap := mcLeap(lmc)
 
@@ -556,31 +673,37 @@ func mcLeap (lmc [15]float64) int {
 
 // (*Calendar) zqAndSMandLunarMonthCode 以比较日期法求算冬月及其余各月名称代码,包含闰月,冬月为0,腊月为1,正月为2,其余类推.闰月多加0.5
 //
-// 农历按中国时间(东八区)计算
-func (c *Calendar) zqAndSMandLunarMonthCode(year int) ([16]float64, [15]float64) {
+// 农历按CalendarConfig.LunarVariant所用的时区计算,中国农历为东八区
+// 用到的Delta T超出有效范围时返回其错误,结果不缓存
+func (c *Calendar) zqAndSMandLunarMonthCode(year int) ([16]float64, [15]float64, error) {
 
 	// 取得以前一年冬至为起点之连续16个中气
-	qss := c.qiSinceWinterSolstice(year)
+	qss, dtErr := c.qiSinceWinterSolstice(year)
 
 	// 求出以含冬至中气为阴历11月(冬月)开始的连续16个朔望月的新月点
-	nm := c.sMsinceWinterSolstice(year, qss[0])
+	nm, err := c.sMsinceWinterSolstice(year, qss[0])
+	if dtErr == nil {
+		dtErr = err
+	}
 
 	// 如果c.lMC记录了该年的数据，则直接返回
 	lmc,err := c.tempData.lMC.getData(year)
 	if err == nil {
-		return nm,lmc
+		return nm,lmc,nil
 	}
 
+	offset := c.lunarTimeOffsetDays()
+
 	// 设定旗标,0表示未遇到闰月,1表示已遇到闰月
 	yz := 0
 
-	if math.Floor(qss[12]+0.5 + cChineseTimeOffsetDays) >= math.Floor(nm[13]+0.5 + cChineseTimeOffsetDays) {
+	if math.Floor(qss[12]+0.5 + offset) >= math.Floor(nm[13]+0.5 + offset) {
 
 		for i := 1; i <= 14; i++ {
 
 			// 至少有一个朔望月不含中气,第一个不含中气的月即为闰月
 			// 若阴历腊月起始日大於冬至中气日,且阴历正月起始日小于或等于大寒中气日,则此月为闰月,其余同理
-			if (nm[i]+0.5 + cChineseTimeOffsetDays) > math.Floor(qss[i-1-yz]+0.5 + cChineseTimeOffsetDays) && math.Floor(nm[i+1]+0.5 + cChineseTimeOffsetDays) <= math.Floor(qss[i-yz]+0.5 + cChineseTimeOffsetDays) {
+			if math.Floor(nm[i]+0.5 + offset) > math.Floor(qss[i-1-yz]+0.5 + offset) && math.Floor(nm[i+1]+0.5 + offset) <= math.Floor(qss[i-yz]+0.5 + offset) {
 				lmc[i] = float64(i) - 0.5
 				yz = 1 // 标示遇到闰月
 			} else {
@@ -594,7 +717,7 @@ func (c *Calendar) zqAndSMandLunarMonthCode(year int) ([16]float64, [15]float64)
 		}
 		for i := 13; i <= 14; i++ { // 处理次一置月年的11月与12月,亦有可能含闰月
 			// 若次一阴历腊月起始日大于附近的冬至中气日,且阴历正月起始日小于或等于大寒中气日,则此月为腊月,次一正月同理.
-			if (nm[i]+0.5 + cChineseTimeOffsetDays) > math.Floor(qss[i-1-yz]+0.5 + cChineseTimeOffsetDays) && math.Floor(nm[i+1]+0.5 + cChineseTimeOffsetDays) <= math.Floor(qss[i-yz]+0.5 + cChineseTimeOffsetDays) {
+			if math.Floor(nm[i]+0.5 + offset) > math.Floor(qss[i-1-yz]+0.5 + offset) && math.Floor(nm[i+1]+0.5 + offset) <= math.Floor(qss[i-yz]+0.5 + offset) {
 				lmc[i] = float64(i) - 0.5
 				yz = 1 // 标示遇到闰月
 			} else {
@@ -603,18 +726,25 @@ func (c *Calendar) zqAndSMandLunarMonthCode(year int) ([16]float64, [15]float64)
 		}
 	}
 
+	if dtErr != nil {
+		return nm, lmc, dtErr
+	}
+
 	c.tempData.lMC.setData(year,lmc)
 
-	return nm, lmc
+	return nm, lmc, nil
 }
 
 
 
 // (*Calendar) sMsinceWinterSolstice 求算以含冬至中气为阴历11月开始的连续16个朔望月
-func (c *Calendar) sMsinceWinterSolstice (year int, dzJd float64) [16]float64 {
+//
+// Delta T超出有效范围时返回其错误,结果不缓存
+func (c *Calendar) sMsinceWinterSolstice (year int, dzJd float64) ([16]float64, error) {
 
 	tnm := [20]float64{}
 	nm := [16]float64{}
+	var dtErr error
 
 	// 如果c.tNM记录了该年的数据，则直接赋值给tnm
 	tNM,err := c.tempData.tNM.getData(year)
@@ -629,25 +759,43 @@ func (c *Calendar) sMsinceWinterSolstice (year int, dzJd float64) [16]float64 {
 		// kn,thejd := meanNewMoon(novemberJd)
 		kn := referenceLunarMonthNum(novemberJd)
 
+		rule := c.lunarRule(year)
+
 		// 求出连续20个朔望月
 		for i := 0; i <= 19; i++ {
 			k := kn + float64(i)
 
 			// 以k值代入求瞬时朔望日
 			// tnm[i] = trueNewMoon(k) + cChineseTimeOffsetDays // 农历计算需要，加上中国(东八区)时差
-			tnm[i] = trueNewMoon(k) // 中国(东八区)时差放在具体计算时调整，此处不做调整
+			if rule == lunarRulePingQiPingShuo {
+				tnm[i] = meanNewMoonJd(k) // 历史农历唐以前用平朔
+			} else {
+				tnm[i] = trueNewMoon(k) // 中国(东八区)时差放在具体计算时调整，此处不做调整
+			}
 
 			// 下式为修正 dynamical time to Universal time
 			// 1为1月，0为前一年12月，-1为前一年11月(当i=0时，i-1代表前一年11月)
-			tnm[i] = Round(tnm[i] - deltaTDays(float64(year), float64(i - 1)), 10)
+			dtd, err := deltaTDays(float64(year), float64(i - 1))
+			if err != nil && dtErr == nil {
+				dtErr = err
+			}
+			tnm[i] = Round(tnm[i] - dtd, 10)
+
+			if rule != lunarRuleDingQiDingShuo {
+				tnm[i] = correctHistoricalNewMoon(tnm[i])
+			}
 		}
 
-		c.tempData.tNM.setData(year,tnm)
+		if dtErr == nil {
+			c.tempData.tNM.setData(year,tnm)
+		}
 	}
 
+	offset := c.lunarTimeOffsetDays()
+
 	var jj = 0
 	for j := 0; j <= 18; j++ {
-		if math.Floor(tnm[j] + 0.5) > math.Floor(dzJd + 0.5) {
+		if math.Floor(tnm[j] + 0.5 + offset) > math.Floor(dzJd + 0.5 + offset) {
 			jj = j
 			break
 		} // 已超过冬至中气(比较日期法)
@@ -657,18 +805,23 @@ func (c *Calendar) sMsinceWinterSolstice (year int, dzJd float64) [16]float64 {
 		nm[k] = tnm[jj-1+k] // 重排索引,使含冬至朔望月的索引为0
 	}
 
-	return nm
+	return nm, dtErr
 }
 
 // (*Calendar) lunarFestival 取农历节日
 func (c *Calendar) lunarFestival (lunarYear,lunarMonth,lunarDay int, isLeap bool) FestivalItem {
 
+	fr := c.festivalRegistry()
+
+	// 节日表修改后清除已缓存的节日
+	c.tempData.lFD.sync(fr.version())
+
 	fds := c.tempData.lFD.getData(lunarYear)
 
 	if len(fds) == 0 {
 
-		// 根据lunarFestivalArray重新格式一个准确的月日为索引的节日map
-		for lfK,lfV := range lunarFestivalArray{
+		// 根据节日表重新格式一个准确的月日为索引的节日map
+		for lfK,lfV := range fr.Festivals(FestivalLunar) {
 			trueK := "" // 经过处理转换成月日的K
 			isleap := false    // 索引中是否指明为闰月
 			isLastDay := false // 索此中是否指明为某月最后一天
@@ -678,7 +831,7 @@ func (c *Calendar) lunarFestival (lunarYear,lunarMonth,lunarDay int, isLeap bool
 			// 索引正则
 			// re := regexp.MustCompile("([0-9]{1,2})?(@?)(M)?(?:([0-9]{1,2})D)?(?:([1-4])W([0-6]))?(\\$)?$").FindStringSubmatch(lfK)
 			// 农历节日索引正则
-			re := regexp.MustCompile("([0-9]{1,2})(@?)M(?:([0-9]{1,2})D)?(\\$)?$").FindStringSubmatch(lfK)
+			re := lunarFestivalRegexp.FindStringSubmatch(lfK)
 
 			if len(re) != 5{
 				continue // 索引格式不正确
@@ -762,19 +915,8 @@ func (c *Calendar) lunarFestival (lunarYear,lunarMonth,lunarDay int, isLeap bool
 	festivalIndex += "M" + strconv.Itoa(lunarDay) + "D"
 
 
-	var fi FestivalItem // 该日的节日
-	if fv, ok := fds[festivalIndex]; ok {
-		for _, v := range fv {
-			v = strings.TrimSpace(v)
-			if svs := strings.Split(v,"*"); len(svs) > 1 {
-				fi.Show = append(fi.Show,svs[1])
-			}else{
-				fi.Secondary = append(fi.Secondary,v)
-			}
-		}
-	}
-
-	return fi
+	// 该日的节日
+	return festivalItemFromNames(fds[festivalIndex])
 }
 
 // DayChinese 农历日汉字表示法
@@ -800,7 +942,8 @@ func DayChinese(d int) string {
 
 // (GZ) String 干支显示
 func (gz GZ) String() string{
-	return fmt.Sprintf("%s%s年%s%s月%s%s日%s%s时", gz.Year.HSN, gz.Year.EBN, gz.Month.HSN, gz.Month.EBN, gz.Day.HSN, gz.Day.EBN, gz.Hour.HSN, gz.Hour.EBN)
+	l := gz.locale.orDefault()
+	return fmt.Sprintf(l.GZFormat, l.gz(gz.Year), l.gz(gz.Month), l.gz(gz.Day), l.gz(gz.Hour))
 }
 
 // (LunarDate) String 农历显示
@@ -810,7 +953,7 @@ func (ld LunarDate)String()string{
 		festivalStr = " " + strings.Join(ld.Festival.Show, ",")
 	}
 
-	return fmt.Sprintf("%d%s%s(%s)年%s%s月%s%s",ld.Year, ld.YearGZ.HSN, ld.YearGZ.EBN, ld.AnimalName, ld.LeapStr, ld.MonthName, ld.DayName, festivalStr)
+	return ld.locale.orDefault().lunarDate(ld) + festivalStr
 }
 
 
@@ -818,101 +961,61 @@ func (ld LunarDate)String()string{
 
 // (*pureJieQi16) getData 读节气缓存年表
 func (pjq *pureJieQi16Temp) getData(k int) ([16]float64, error) {
-	pjq.mu.RLock()
-	defer pjq.mu.RUnlock()
-	var rv [16]float64
-	if pjq.data == nil {
-		pjq.data = make(map[int][16]float64)
-	}
-	if v, ok := pjq.data[k]; ok {
-		return v,nil
+	if v, ok := pjq.cache.get(cacheKey{pjq.kind, k, pjq.variant}); ok {
+		return v.([16]float64),nil
 	}
 
+	var rv [16]float64
 	return rv,errors.New("缓存数据不存在！")
 }
 
 // (*pureJieQi16) getData 写节气缓存年表
 func (pjq *pureJieQi16Temp) setData (k int, v [16]float64){
-	pjq.mu.Lock()
-	defer pjq.mu.Unlock()
-	if pjq.data == nil {
-		pjq.data = make(map[int][16]float64)
-	}
-	pjq.data[k] = v
+	pjq.cache.set(cacheKey{pjq.kind, k, pjq.variant}, v)
 }
 
 // (*trueNewMoon20Temp) getData 读20个新月点缓存年表
 func (tnm *trueNewMoon20Temp) getData(k int) ([20]float64, error) {
-	tnm.mu.RLock()
-	defer tnm.mu.RUnlock()
-	var rv [20]float64
-	if tnm.data == nil {
-		tnm.data = make(map[int][20]float64)
-	}
-	if v, ok := tnm.data[k]; ok {
-		return v,nil
+	if v, ok := tnm.cache.get(cacheKey{tnm.kind, k, tnm.variant}); ok {
+		return v.([20]float64),nil
 	}
 
+	var rv [20]float64
 	return rv,errors.New("缓存数据不存在！")
 }
 
 // (*trueNewMoon20Temp) getData 写20个新月点缓存年表
 func (tnm *trueNewMoon20Temp) setData (k int, v [20]float64){
-	tnm.mu.Lock()
-	defer tnm.mu.Unlock()
-	if tnm.data == nil {
-		tnm.data = make(map[int][20]float64)
-	}
-	tnm.data[k] = v
+	tnm.cache.set(cacheKey{tnm.kind, k, tnm.variant}, v)
 }
 
 // (*lunarMonthCode15Temp) getData 读农历月份代码缓存年表
 func (mc *lunarMonthCode15Temp) getData(k int) ([15]float64, error) {
-	mc.mu.RLock()
-	defer mc.mu.RUnlock()
-	var rv [15]float64
-	if mc.data == nil {
-		mc.data = make(map[int][15]float64)
-	}
-	if v, ok := mc.data[k]; ok {
-		return v,nil
+	if v, ok := mc.cache.get(cacheKey{mc.kind, k, mc.variant}); ok {
+		return v.([15]float64),nil
 	}
 
+	var rv [15]float64
 	return rv,errors.New("缓存数据不存在！")
 }
 
 // (*lunarMonthCode15Temp) getData 写农历月份代码缓存年表
 func (mc *lunarMonthCode15Temp) setData (k int, v [15]float64){
-	mc.mu.Lock()
-	defer mc.mu.Unlock()
-	if mc.data == nil {
-		mc.data = make(map[int][15]float64)
-	}
-	mc.data[k] = v
+	mc.cache.set(cacheKey{mc.kind, k, mc.variant}, v)
 }
 
 //
 // (*lunarMonthDays15Temp) getData 读农历月份天数缓存年表
 func (md *lunarMonthDays15Temp) getData(k int) ([15]int, error) {
-	md.mu.RLock()
-	defer md.mu.RUnlock()
-	var rv [15]int
-	if md.data == nil {
-		md.data = make(map[int][15]int)
-	}
-	if v, ok := md.data[k]; ok {
-		return v,nil
+	if v, ok := md.cache.get(cacheKey{md.kind, k, md.variant}); ok {
+		return v.([15]int),nil
 	}
 
+	var rv [15]int
 	return rv,errors.New("缓存数据不存在！")
 }
 
 // (*lunarMonthDays15Temp) getData 写农历月份天数缓存年表
 func (md *lunarMonthDays15Temp) setData (k int, v [15]int){
-	md.mu.Lock()
-	defer md.mu.Unlock()
-	if md.data == nil {
-		md.data = make(map[int][15]int)
-	}
-	md.data[k] = v
+	md.cache.set(cacheKey{md.kind, k, md.variant}, v)
 }
\ No newline at end of file
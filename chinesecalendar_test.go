@@ -82,7 +82,7 @@ func TestCalendar_pureJieSinceSpring(t *testing.T) {
 	dc := DefaultCalendar()
 
 	year := 2021
-	jss := dc.pureJieSinceSpring(year)
+	jss, _ := dc.pureJieSinceSpring(year)
 
 	for i, v := range jss {
 		t.Logf("%d %.10f", i, v)
@@ -93,9 +93,51 @@ func TestCalendar_qiSinceWinterSolstice(t *testing.T) {
 	dc := DefaultCalendar()
 
 	year := 2021
-	qss := dc.qiSinceWinterSolstice(year)
+	qss, _ := dc.qiSinceWinterSolstice(year)
 
 	for i, v := range qss {
 		t.Logf("%d %.10f", i, v)
 	}
 }
+
+// 公历农历互转,含新月在东八区晚间及冬至与新月同日的年份
+func TestCalendar_LunarRoundTrip(t *testing.T) {
+	c := NewCalendar(CalendarConfig{TimeZoneName: "America/New_York"})
+	for d := time.Date(2014, 1, 1, 0, 0, 0, 0, c.loc); d.Year() < 2016; d = d.AddDate(0, 0, 1) {
+		ld := c.GregorianToLunar(d.Year(), int(d.Month()), d.Day())
+		gd, err := c.LunarToGregorian(ld.Year, ld.Month, ld.Day, ld.LeapStr != "")
+		if err != nil || gd.Format("2006-01-02") != d.Format("2006-01-02") {
+			t.Fatal(d, ld, gd, err)
+		}
+	}
+
+	if c.LunarLeap(2014) == 9 && c.LunarLeap(2015) == 0 && c.LunarLeap(2033) == 11 {
+		t.Log("passed")
+	} else {
+		t.Error(c.LunarLeap(2014), c.LunarLeap(2015), c.LunarLeap(2033))
+	}
+}
+
+// 农历日期以东八区的日期为准,返回日历时区该日的0时
+//
+// 2014年和2015年春节的新月在东八区凌晨(UT前一日),以前按UT日期算早一天,且返回的是UT的0时
+func TestCalendar_LunarToGregorianChinaDay(t *testing.T) {
+	for _, tz := range []string{"Asia/Shanghai", "America/New_York", "UTC"} {
+		c := NewCalendar(CalendarConfig{TimeZoneName: tz})
+		for _, tt := range []struct {
+			year int
+			want string
+		}{
+			{2014, "2014-01-31"},
+			{2015, "2015-02-19"},
+		} {
+			gd, err := c.LunarToGregorian(tt.year, 1, 1, false)
+			if err == nil && gd.Format("2006-01-02") == tt.want && gd.Location() == c.loc &&
+				gd.Hour() == 0 && gd.Minute() == 0 && gd.Second() == 0 {
+				t.Log("passed")
+			} else {
+				t.Error(tz, tt, gd, err)
+			}
+		}
+	}
+}
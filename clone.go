@@ -1,5 +1,7 @@
 package gocalendar
 
+import "time"
+
 // (*SolarTermItem) clone
 func (sti *SolarTermItem) clone() *SolarTermItem {
 	if sti == nil {
@@ -11,6 +13,8 @@ func (sti *SolarTermItem) clone() *SolarTermItem {
 		Index: sti.Index,
 		Name:  sti.Name,
 		Time:  &t,
+
+		locale: sti.locale,
 	}
 }
 
@@ -44,6 +48,81 @@ func (fi *FestivalItem) clone() *FestivalItem {
 	}
 }
 
+// (*MoonPhaseItem) clone
+func (mpi *MoonPhaseItem) clone() *MoonPhaseItem {
+	if mpi == nil {
+		return nil
+	}
+
+	var t *time.Time
+	if mpi.Time != nil {
+		mt := mpi.Time.AddDate(0, 0, 0)
+		t = &mt
+	}
+
+	return &MoonPhaseItem{
+		Index:      mpi.Index,
+		Name:       mpi.Name,
+		Time:       t,
+		Fraction:   mpi.Fraction,
+		PhaseAngle: mpi.PhaseAngle,
+	}
+}
+
+// (*SunItem) clone
+func (si *SunItem) clone() *SunItem {
+	if si == nil {
+		return nil
+	}
+
+	ct := func(t *time.Time) *time.Time {
+		if t == nil {
+			return nil
+		}
+		nt := t.AddDate(0, 0, 0)
+		return &nt
+	}
+
+	return &SunItem{
+		Sunrise:          ct(si.Sunrise),
+		SolarNoon:        ct(si.SolarNoon),
+		Sunset:           ct(si.Sunset),
+		CivilDawn:        ct(si.CivilDawn),
+		CivilDusk:        ct(si.CivilDusk),
+		NauticalDawn:     ct(si.NauticalDawn),
+		NauticalDusk:     ct(si.NauticalDusk),
+		AstronomicalDawn: ct(si.AstronomicalDawn),
+		AstronomicalDusk: ct(si.AstronomicalDusk),
+		PolarDay:         si.PolarDay,
+		PolarNight:       si.PolarNight,
+	}
+}
+
+// (*AlmanacItem) clone
+func (ai *AlmanacItem) clone() *AlmanacItem {
+	if ai == nil {
+		return nil
+	}
+
+	var pengZu, yi, ji []string
+	pengZu = append(pengZu, ai.PengZu...)
+	yi = append(yi, ai.Yi...)
+	ji = append(ji, ai.Ji...)
+
+	return &AlmanacItem{
+		OfficerIndex: ai.OfficerIndex,
+		Officer:      ai.Officer,
+		MansionIndex: ai.MansionIndex,
+		Mansion:      ai.Mansion,
+		Clash:        ai.Clash.clone(),
+		ClashAnimal:  ai.ClashAnimal,
+		Sha:          ai.Sha,
+		PengZu:       pengZu,
+		Yi:           yi,
+		Ji:           ji,
+	}
+}
+
 // (*GZItem) clone
 func (gzi *GZItem) clone() *GZItem {
 	if gzi == nil {
@@ -64,11 +143,20 @@ func (gz *GZ) clone() *GZ {
 		return nil
 	}
 
+	var st *time.Time
+	if gz.SolarTime != nil {
+		t := gz.SolarTime.AddDate(0, 0, 0)
+		st = &t
+	}
+
 	return &GZ{
 		Year:  gz.Year.clone(),
 		Month: gz.Month.clone(),
 		Day:   gz.Day.clone(),
 		Hour:  gz.Hour.clone(),
+
+		SolarTime: st,
+		locale:    gz.locale,
 	}
 }
 
@@ -90,6 +178,68 @@ func (ld *LunarDate) clone() *LunarDate {
 		AnimalName:    ld.AnimalName,
 		YearGZ:        ld.YearGZ.clone(),
 		Festival:      ld.Festival.clone(),
+		Eras:          append([]EraYear(nil), ld.Eras...),
+		locale:        ld.locale,
+	}
+}
+
+// (*HijriDate) clone
+func (hd *HijriDate) clone() *HijriDate {
+	if hd == nil {
+		return nil
+	}
+
+	return &HijriDate{
+		Year:      hd.Year,
+		Month:     hd.Month,
+		Day:       hd.Day,
+		MonthName: hd.MonthName,
+		MonthDays: hd.MonthDays,
+		Festival:  hd.Festival.clone(),
+		locale:    hd.locale,
+	}
+}
+
+// (*HebrewDate) clone
+func (hd *HebrewDate) clone() *HebrewDate {
+	if hd == nil {
+		return nil
+	}
+
+	return &HebrewDate{
+		Year:       hd.Year,
+		Month:      hd.Month,
+		Day:        hd.Day,
+		MonthName:  hd.MonthName,
+		MonthDays:  hd.MonthDays,
+		IsLeapYear: hd.IsLeapYear,
+		Festival:   hd.Festival.clone(),
+		locale:     hd.locale,
+	}
+}
+
+// (*PersianDate) clone
+func (pd *PersianDate) clone() *PersianDate {
+	if pd == nil {
+		return nil
+	}
+
+	var nowruz *time.Time
+	if pd.Nowruz != nil {
+		t := pd.Nowruz.AddDate(0, 0, 0)
+		nowruz = &t
+	}
+
+	return &PersianDate{
+		Year:       pd.Year,
+		Month:      pd.Month,
+		Day:        pd.Day,
+		MonthName:  pd.MonthName,
+		MonthDays:  pd.MonthDays,
+		IsLeapYear: pd.IsLeapYear,
+		Nowruz:     nowruz,
+		Festival:   pd.Festival.clone(),
+		locale:     pd.locale,
 	}
 }
 
@@ -109,11 +259,20 @@ func (ci *CalendarItem) clone() *CalendarItem {
 		GZ:           ci.GZ.clone(),
 		LunarDate:    ci.LunarDate.clone(),
 		StarSign:     ci.StarSign.clone(),
+		DayType:      ci.DayType,
+		DayTypeName:  ci.DayTypeName,
+		MoonPhase:    ci.MoonPhase.clone(),
+		Sun:          ci.Sun.clone(),
+		Almanac:      ci.Almanac.clone(),
+		HijriDate:    ci.HijriDate.clone(),
+		HebrewDate:   ci.HebrewDate.clone(),
+		PersianDate:  ci.PersianDate.clone(),
+		locale:       ci.locale,
 	}
 }
 
 // (*Calendar) clone 克隆一个Calendar
-// 该克隆不对临时数据克隆,而是清空临时数据
+// 该克隆不对临时数据克隆,而是清空临时数据,天文计算缓存与c共享
 func (c *Calendar) Clone() *Calendar {
 	if c == nil {
 		return nil
@@ -128,11 +287,13 @@ func (c *Calendar) Clone() *Calendar {
 		}
 	}
 
-	return &Calendar{
-		Items:    items,
-		config:   c.config.clone(),
-		loc:      c.loc,
-		rawTime:  &rawT,
-		tempData: newCalendarTempData(),
+	nc := &Calendar{
+		Items:   items,
+		config:  c.config.clone(),
+		loc:     c.loc,
+		rawTime: &rawT,
 	}
+	nc.tempData = nc.newCalendarTempData()
+
+	return nc
 }
@@ -0,0 +1,45 @@
+// gocalendar-server 日历HTTP/JSON服务
+//
+// 用法:
+//
+//	gocalendar-server -addr :8080 -tz Asia/Shanghai
+//
+// 接口说明见 /openapi.json
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/liujiawm/gocalendar"
+	"github.com/liujiawm/gocalendar/server"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "监听地址")
+	tz := flag.String("tz", "", "默认时区名称,默认本地时区")
+	locale := flag.String("locale", gocalendar.DefaultLocaleName, "默认语言")
+	flag.Parse()
+
+	srv := &http.Server{
+		Addr: *addr,
+		Handler: server.New(gocalendar.CalendarConfig{
+			Grid:            gocalendar.GridMonth,
+			TimeZoneName:    *tz,
+			Locale:          *locale,
+			SolarTerms:      true,
+			Lunar:           true,
+			HeavenlyEarthly: true,
+			NightZiHour:     true,
+			StarSign:        true,
+			Holiday:         true,
+		}),
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+
+	log.Printf("gocalendar-server listening on %s", *addr)
+	log.Fatal(srv.ListenAndServe())
+}
@@ -0,0 +1,404 @@
+// gocalendar 命令行日历
+//
+// 用法:
+//
+//	gocalendar month [YEAR MONTH]          月历表
+//	gocalendar lunar DATE                  公历转农历,DATE如2021-05-06
+//	gocalendar solar DATE [--leap]         农历转公历,DATE如2020-04-14
+//	gocalendar terms YEAR                  该年的节气
+//	gocalendar gz DATETIME                 干支,DATETIME如"2021-05-06 23:30"
+//
+// 各子命令都支持 --tz 时区名称、--json 输出JSON、--night-zi 区分早晚子时、--locale 语言
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/liujiawm/gocalendar"
+)
+
+const usage = `用法: gocalendar <命令> [参数] [选项]
+
+命令:
+  month [YEAR MONTH]    月历表,默认本月
+  lunar DATE            公历转农历,DATE如2021-05-06
+  solar DATE            农历转公历,DATE如2020-04-14,公元前如-0100-01-01,闰月加--leap
+  terms YEAR            该年的节气
+  gz DATETIME           干支,DATETIME如"2021-05-06 23:30"
+
+选项:
+  --tz NAME             时区名称,默认本地时区
+  --json                输出JSON
+  --night-zi            区分早晚子时
+  --locale NAME         语言,默认zh-Hans
+  --first-week N        (month)第一列是周几,0为周日
+  --leap                (solar)闰月
+`
+
+// 接受的日期时间格式
+var dateTimeLayouts = []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// type options struct 命令行选项
+type options struct {
+	tz        string
+	json      bool
+	nightZi   bool
+	locale    string
+	firstWeek int
+	leap      bool
+}
+
+// run 执行命令,结果写入stdout
+func run(args []string, stdout io.Writer) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		fmt.Fprint(stdout, usage)
+		return nil
+	}
+
+	cmd := args[0]
+
+	var opts options
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.StringVar(&opts.tz, "tz", "", "时区名称")
+	fs.BoolVar(&opts.json, "json", false, "输出JSON")
+	fs.BoolVar(&opts.nightZi, "night-zi", false, "区分早晚子时")
+	fs.StringVar(&opts.locale, "locale", gocalendar.DefaultLocaleName, "语言")
+	fs.IntVar(&opts.firstWeek, "first-week", 0, "第一列是周几")
+	fs.BoolVar(&opts.leap, "leap", false, "闰月")
+
+	pos, err := parseArgs(fs, args[1:])
+	if err != nil {
+		return err
+	}
+
+	if opts.tz != "" {
+		if _, err := time.LoadLocation(opts.tz); err != nil {
+			return fmt.Errorf("时区错误: %s", opts.tz)
+		}
+	}
+
+	c := gocalendar.NewCalendar(gocalendar.CalendarConfig{
+		Grid:            gocalendar.GridMonth,
+		FirstWeek:       opts.firstWeek,
+		TimeZoneName:    opts.tz,
+		Locale:          opts.locale,
+		SolarTerms:      true,
+		Lunar:           true,
+		HeavenlyEarthly: true,
+		NightZiHour:     opts.nightZi,
+		StarSign:        true,
+	})
+	loc, _ := time.LoadLocation(c.GetConfig().TimeZoneName)
+
+	switch cmd {
+	case "month":
+		return runMonth(c, loc, pos, opts, stdout)
+	case "lunar":
+		return runLunar(c, loc, pos, opts, stdout)
+	case "solar":
+		return runSolar(c, pos, opts, stdout)
+	case "terms":
+		return runTerms(c, pos, opts, stdout)
+	case "gz":
+		return runGZ(c, loc, pos, opts, stdout)
+	}
+
+	return fmt.Errorf("未知的命令: %s", cmd)
+}
+
+// parseArgs 解析选项,选项可以在位置参数之前或之后
+//
+// 负号加数字开头的参数(如公元前的日期-0100-01-01)是位置参数,不当作选项
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var pos []string
+	for {
+		i := negativeArgIndex(fs, args)
+		if err := fs.Parse(args[:i]); err != nil {
+			return nil, err
+		}
+		if fs.NArg() > 0 {
+			pos = append(pos, fs.Arg(0))
+			args = append(append([]string{}, fs.Args()[1:]...), args[i:]...)
+			continue
+		}
+		if i == len(args) {
+			return pos, nil
+		}
+		pos = append(pos, args[i])
+		args = args[i+1:]
+	}
+}
+
+// negativeArgIndex 第一个负号加数字开头的位置参数的索引,没有则为len(args)
+//
+// 需要值的选项之后的参数是该选项的值,"--"之后的参数都是位置参数,由fs.Parse处理
+func negativeArgIndex(fs *flag.FlagSet, args []string) int {
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a == "--" {
+			break
+		}
+		if len(a) > 1 && a[0] == '-' && a[1] >= '0' && a[1] <= '9' {
+			return i
+		}
+		if len(a) < 2 || a[0] != '-' || strings.Contains(a, "=") {
+			continue
+		}
+		f := fs.Lookup(strings.TrimLeft(a, "-"))
+		if f == nil {
+			continue
+		}
+		if bf, ok := f.Value.(interface{ IsBoolFlag() bool }); !ok || !bf.IsBoolFlag() {
+			i++
+		}
+	}
+	return len(args)
+}
+
+// parseDateTime 解析loc时区的日期时间
+func parseDateTime(s string, loc *time.Location) (time.Time, error) {
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("日期时间格式错误: %s", s)
+}
+
+// parseDate 解析 年-月-日,不检查日期是否存在(农历日期用)
+func parseDate(s string) (year, month, day int, err error) {
+	// 公元前的年份以负号开头
+	neg := strings.HasPrefix(s, "-")
+	parts := strings.Split(strings.TrimPrefix(s, "-"), "-")
+	if len(parts) == 3 {
+		year, err = strconv.Atoi(parts[0])
+		if err == nil {
+			month, err = strconv.Atoi(parts[1])
+		}
+		if err == nil {
+			day, err = strconv.Atoi(parts[2])
+		}
+		if err == nil {
+			if neg {
+				year = -year
+			}
+			return year, month, day, nil
+		}
+	}
+	return 0, 0, 0, fmt.Errorf("日期格式错误: %s", s)
+}
+
+// writeJSON 输出缩进的JSON
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// runMonth 月历表
+func runMonth(c *gocalendar.Calendar, loc *time.Location, pos []string, opts options, w io.Writer) error {
+	now := time.Now().In(loc)
+	year, month := now.Year(), int(now.Month())
+	switch len(pos) {
+	case 0:
+	case 2:
+		var err1, err2 error
+		year, err1 = strconv.Atoi(pos[0])
+		month, err2 = strconv.Atoi(pos[1])
+		if err1 != nil || err2 != nil || month < 1 || month > 12 {
+			return fmt.Errorf("年月错误: %s %s", pos[0], pos[1])
+		}
+	default:
+		return errors.New("month命令的参数为 YEAR MONTH")
+	}
+
+	items := c.GenerateWithDate(year, month, 1)
+	if opts.json {
+		return writeJSON(w, items)
+	}
+
+	const cellWidth = 12
+
+	fmt.Fprintf(w, "%d-%02d\n", year, month)
+
+	cfg := c.GetConfig()
+	weekdays := gocalendar.LookupLocale(cfg.Locale).Weekdays
+	for i := 0; i < 7; i++ {
+		fmt.Fprint(w, pad(weekdays[(cfg.FirstWeek+i)%7], cellWidth))
+	}
+	fmt.Fprintln(w)
+
+	for i, item := range items {
+		cell := ""
+		if item.IsAccidental == 0 {
+			mark := " "
+			if item.IsToday == 1 {
+				mark = "*"
+			}
+			cell = fmt.Sprintf("%s%2d %s", mark, item.Time.Day(), dayLabel(item))
+		}
+		fmt.Fprint(w, pad(cell, cellWidth))
+		if i%7 == 6 {
+			fmt.Fprintln(w)
+		}
+	}
+
+	return nil
+}
+
+// dayLabel 日历表中日期下方的文字:节日、节气、农历初一显示月份,否则显示农历日
+func dayLabel(item *gocalendar.CalendarItem) string {
+	ld := item.LunarDate
+	switch {
+	case ld != nil && ld.Festival != nil && len(ld.Festival.Show) > 0:
+		return ld.Festival.Show[0]
+	case item.Festival != nil && len(item.Festival.Show) > 0:
+		return item.Festival.Show[0]
+	case item.SolarTerm != nil:
+		return item.SolarTerm.Name
+	case ld == nil:
+		return ""
+	case ld.Day == 1:
+		return ld.LeapStr + ld.MonthName
+	}
+	return ld.DayName
+}
+
+// displayWidth 字符串在终端中的显示宽度,中日韩文字按2计
+func displayWidth(s string) int {
+	n := 0
+	for _, r := range s {
+		if r >= 0x1100 && (r <= 0x115f || (r >= 0x2e80 && r <= 0xa4cf) || (r >= 0xac00 && r <= 0xd7a3) || (r >= 0xf900 && r <= 0xfaff) || (r >= 0xff00 && r <= 0xff60)) {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// pad 右侧补空格到显示宽度width,超出则截断
+func pad(s string, width int) string {
+	for displayWidth(s) > width-1 {
+		_, size := utf8.DecodeLastRuneInString(s)
+		s = s[:len(s)-size]
+	}
+	return s + strings.Repeat(" ", width-displayWidth(s))
+}
+
+// runLunar 公历转农历
+func runLunar(c *gocalendar.Calendar, loc *time.Location, pos []string, opts options, w io.Writer) error {
+	if len(pos) != 1 {
+		return errors.New("lunar命令的参数为 DATE")
+	}
+	t, err := parseDateTime(pos[0], loc)
+	if err != nil {
+		return err
+	}
+
+	ld, err := c.GregorianToLunarE(context.Background(), t.Year(), int(t.Month()), t.Day())
+	if err != nil {
+		return err
+	}
+	if opts.json {
+		return writeJSON(w, ld)
+	}
+
+	fmt.Fprintln(w, ld)
+	return nil
+}
+
+// runSolar 农历转公历
+func runSolar(c *gocalendar.Calendar, pos []string, opts options, w io.Writer) error {
+	if len(pos) != 1 {
+		return errors.New("solar命令的参数为 DATE")
+	}
+	year, month, day, err := parseDate(pos[0])
+	if err != nil {
+		return err
+	}
+
+	t, err := c.LunarToGregorianE(context.Background(), year, month, day, opts.leap)
+	if err != nil {
+		return err
+	}
+	if opts.json {
+		return writeJSON(w, map[string]string{"date": t.Format("2006-01-02")})
+	}
+
+	fmt.Fprintln(w, t.Format("2006-01-02"))
+	return nil
+}
+
+// runTerms 一年的节气
+func runTerms(c *gocalendar.Calendar, pos []string, opts options, w io.Writer) error {
+	if len(pos) != 1 {
+		return errors.New("terms命令的参数为 YEAR")
+	}
+	year, err := strconv.Atoi(pos[0])
+	if err != nil {
+		return fmt.Errorf("年份错误: %s", pos[0])
+	}
+
+	sts, err := c.SolarTermsE(context.Background(), year)
+	if err != nil {
+		return err
+	}
+
+	// SolarTerms包含上一年的冬至和下一年的小寒,只取该年的
+	var terms []*gocalendar.SolarTermItem
+	for _, st := range sts {
+		if st.Time.Year() == year {
+			terms = append(terms, st)
+		}
+	}
+
+	if opts.json {
+		return writeJSON(w, terms)
+	}
+	for _, st := range terms {
+		fmt.Fprintln(w, pad(st.Name, 8)+st.Time.Format("2006-01-02 15:04:05"))
+	}
+	return nil
+}
+
+// runGZ 干支
+func runGZ(c *gocalendar.Calendar, loc *time.Location, pos []string, opts options, w io.Writer) error {
+	if len(pos) != 1 {
+		return errors.New("gz命令的参数为 DATETIME")
+	}
+	t, err := parseDateTime(pos[0], loc)
+	if err != nil {
+		return err
+	}
+
+	gz, err := c.ChineseSexagenaryCycleE(context.Background(), t)
+	if err != nil {
+		return err
+	}
+	if opts.json {
+		return writeJSON(w, gz)
+	}
+
+	fmt.Fprintln(w, gz)
+	return nil
+}
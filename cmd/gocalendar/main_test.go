@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"lunar", "2021-05-06", "--tz", "Asia/Shanghai"}, "2021辛丑(牛)年三月廿五\n"},
+		{[]string{"solar", "--leap", "2020-04-14"}, "2020-06-05\n"},
+		{[]string{"gz", "2021-05-06 23:30", "--tz", "Asia/Shanghai", "--night-zi"}, "辛丑年癸巳月甲寅日丙子时\n"},
+		{[]string{"gz", "2021-05-06 23:30", "--tz", "Asia/Shanghai"}, "辛丑年癸巳月乙卯日丙子时\n"},
+		{[]string{"lunar", "2021-05-06", "--locale", "en"}, "Month 3 Day 25, 2021 Xin-Chou (Ox)\n"},
+		// 公元前的日期以负号开头,不当作选项
+		{[]string{"solar", "-0100-01-01", "--tz", "Asia/Shanghai"}, "-0100-02-19\n"},
+		{[]string{"solar", "--tz", "Asia/Shanghai", "-0100-01-01"}, "-0100-02-19\n"},
+		{[]string{"solar", "--tz", "Asia/Shanghai", "--", "-0100-01-01"}, "-0100-02-19\n"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := run(tt.args, &buf); err != nil {
+			t.Error(tt.args, err)
+			continue
+		}
+		if buf.String() == tt.want {
+			t.Log("passed")
+		} else {
+			t.Error(tt.args, buf.String())
+		}
+	}
+}
+
+func TestRun_Month(t *testing.T) {
+	var buf bytes.Buffer
+	if err := run([]string{"month", "2021", "5", "--tz", "Asia/Shanghai", "--first-week", "1"}, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	// 2021年5月1日是周六,第一列是周一时第一行有5个空格子
+	if len(lines) == 8 && strings.HasPrefix(lines[1], "周一") && strings.HasPrefix(strings.TrimSpace(lines[2]), "1 劳动节") && strings.Contains(lines[3], "5 立夏") {
+		t.Log("passed")
+	} else {
+		t.Error(buf.String())
+	}
+}
+
+func TestRun_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := run([]string{"terms", "2021", "--json", "--tz", "Asia/Shanghai"}, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var terms []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &terms); err != nil {
+		t.Fatal(err)
+	}
+	if len(terms) == 24 && terms[0].Name == "小寒" && terms[23].Name == "冬至" {
+		t.Log("passed")
+	} else {
+		t.Error(terms)
+	}
+}
+
+func TestRun_Error(t *testing.T) {
+	for _, args := range [][]string{
+		{"unknown"},
+		{"lunar", "2021-02-30"},
+		{"solar", "2021-04-14", "--leap"},
+		{"terms", "3001"},
+		{"month", "2021"},
+		{"gz", "now", "--tz", "Nowhere/City"},
+	} {
+		var buf bytes.Buffer
+		if err := run(args, &buf); err != nil {
+			t.Log("passed", err)
+		} else {
+			t.Error(args, buf.String())
+		}
+	}
+}
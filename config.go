@@ -11,18 +11,46 @@ const (
 	GridDay int = iota
 	GridWeek
 	GridMonth
+	GridYear
+	GridLunarMonth
+	GridSolarTermMonth
 )
 
 // type CalendarConfig struct 配置
 type CalendarConfig struct {
-	Grid            int    // 取日历方式,GridDay按天取日历,GridWeek按周取日历,GridMonth按月取日历
+	Grid            int    // 取日历方式,GridDay按天取日历,GridWeek按周取日历,GridMonth按月取日历,GridYear按年取日历,GridLunarMonth按农历月取日历,GridSolarTermMonth按节气月取日历
 	FirstWeek       int    // 日历显示时第一列显示周几，(日历表第一列是周几,0周日,依次最大值6)
 	TimeZoneName    string // 时区名称,需zoneinfo支持的时区名称
+	Locale          string // 语言名称,需已注册的语言,默认DefaultLocaleName
 	SolarTerms      bool   // 读取节气 bool
 	Lunar           bool   // 读取农历 bool
 	HeavenlyEarthly bool   // 读取干支 bool
 	NightZiHour     bool   // 区分早晚子时，true则 23:00-24:00 00:00-01:00为子时，否则00:00-02:00为子时
 	StarSign        bool   // 读取星座
+	Holiday         bool   // 读取日期类型(工作日、周末、法定节假日、调休上班),默认不读取
+	MoonPhase       bool   // 读取月相
+	Sun             bool   // 读取日出日落及晨昏蒙影时刻,需设置Latitude和Longitude
+	TrueSolarTime   bool   // 干支的日柱和时柱按真太阳时计算,需设置Longitude和LocationSet,未设置LocationSet时按钟面时间计算
+	Almanac         bool   // 读取黄历(建除、二十八宿、冲煞、彭祖百忌、宜忌)
+	LunarTable      bool   // 农历转换在内置农历表覆盖的年份(LunarTableRange)内查表,其它年份仍按天文算法计算,只用于中国农历
+	LunarVariant    int    // 农历的变体,ChineseLunar中国农历,KoreanLunar韩国农历,VietnameseLunar越南农历
+	HistoricalLunar bool   // 1645年以前的农历按当时的历法规则推算,唐以前平气平朔,唐至明平气定朔,除天启七年八月外没有按历书校对
+	EraNames        bool   // 农历附带年号纪年(LunarDate.Eras),年号表收录明(1368年)以后的年号
+	Hijri           bool   // 读取伊斯兰历
+	HijriMethod     int    // 伊斯兰历算法,HijriTabular算术历法,HijriAstronomical按见月推算,需设置LocationSet
+	Hebrew          bool   // 读取希伯来历
+	Persian         bool   // 读取波斯历
+	PersianMethod   int    // 波斯历算法,PersianAstronomical按春分与德黑兰正午推算,PersianArithmetic按33年8闰的算术历法
+
+	Latitude    float64 // 地理纬度(度),北纬为正
+	Longitude   float64 // 地理经度(度),东经为正
+	Elevation   float64 // 海拔(米)
+	LocationSet bool    // 已设置Latitude、Longitude和Elevation,0度也是有效的坐标,所以需要另外标明
+
+	Festivals       *FestivalRegistry // 节日表,nil则使用默认节日表DefaultFestivalRegistry()
+	HolidaySchedule *HolidaySchedule  // 节假日安排,nil则使用默认节假日安排DefaultHolidaySchedule()
+	AlmanacRules    *AlmanacRules     // 黄历宜忌规则,nil则使用默认规则DefaultAlmanacRules()
+	AstroCache      *AstroCache       // 节气和农历的天文计算缓存,nil则使用进程内共享的DefaultAstroCache()
 }
 
 // defaultConfig 新的默认配置
@@ -31,6 +59,7 @@ func defaultConfig() CalendarConfig {
 		Grid:            GridMonth,
 		FirstWeek:       0,
 		TimeZoneName:    time.Local.String(),
+		Locale:          DefaultLocaleName,
 		SolarTerms:      true,
 		Lunar:           true,
 		HeavenlyEarthly: true,
@@ -45,11 +74,34 @@ func (cfg *CalendarConfig) clone() *CalendarConfig {
 		Grid:            cfg.Grid,
 		FirstWeek:       cfg.FirstWeek,
 		TimeZoneName:    cfg.TimeZoneName,
+		Locale:          cfg.Locale,
 		SolarTerms:      cfg.SolarTerms,
 		Lunar:           cfg.Lunar,
 		HeavenlyEarthly: cfg.HeavenlyEarthly,
 		NightZiHour:     cfg.NightZiHour,
 		StarSign:        cfg.StarSign,
+		Holiday:         cfg.Holiday,
+		MoonPhase:       cfg.MoonPhase,
+		Sun:             cfg.Sun,
+		TrueSolarTime:   cfg.TrueSolarTime,
+		Almanac:         cfg.Almanac,
+		LunarTable:      cfg.LunarTable,
+		LunarVariant:    cfg.LunarVariant,
+		HistoricalLunar: cfg.HistoricalLunar,
+		EraNames:        cfg.EraNames,
+		Hijri:           cfg.Hijri,
+		HijriMethod:     cfg.HijriMethod,
+		Hebrew:          cfg.Hebrew,
+		Persian:         cfg.Persian,
+		PersianMethod:   cfg.PersianMethod,
+		Latitude:        cfg.Latitude,
+		Longitude:       cfg.Longitude,
+		Elevation:       cfg.Elevation,
+		LocationSet:     cfg.LocationSet,
+		Festivals:       cfg.Festivals,
+		HolidaySchedule: cfg.HolidaySchedule,
+		AlmanacRules:    cfg.AlmanacRules,
+		AstroCache:      cfg.AstroCache,
 	}
 }
 
@@ -0,0 +1,51 @@
+package gocalendar
+
+// 内置的年号表,收录明(1368年)以后的年号,包括明、南明、明郑、后金、清、大顺、大西、吴周及中华民国
+//
+// 明以前的年号尚未收录;太平天国用自己的天历,日期与农历不同,也不收录。
+// 按起始日排列,同一时期并存的政权各有一条,如清顺治与南明永历。
+// 农历的起止日按年号实际行用的日期,如建文四年六月朱棣即位后改称洪武三十五年,
+// 景泰八年正月英宗复辟后改元天顺;月末的日期记为30日
+var eraTable = []Era{
+	{"明", "太祖朱元璋", "洪武", 1368, EraDate{1368, 1, 1, false}, EraDate{1398, 12, 30, false}, false},
+	{"明", "惠帝朱允炆", "建文", 1399, EraDate{1399, 1, 1, false}, EraDate{1402, 6, 16, false}, false},
+	{"明", "成祖朱棣", "洪武", 1368, EraDate{1402, 6, 17, false}, EraDate{1402, 12, 30, false}, false},
+	{"明", "成祖朱棣", "永乐", 1403, EraDate{1403, 1, 1, false}, EraDate{1424, 12, 30, false}, false},
+	{"明", "仁宗朱高炽", "洪熙", 1425, EraDate{1425, 1, 1, false}, EraDate{1425, 12, 30, false}, false},
+	{"明", "宣宗朱瞻基", "宣德", 1426, EraDate{1426, 1, 1, false}, EraDate{1435, 12, 30, false}, false},
+	{"明", "英宗朱祁镇", "正统", 1436, EraDate{1436, 1, 1, false}, EraDate{1449, 12, 30, false}, false},
+	{"明", "代宗朱祁钰", "景泰", 1450, EraDate{1450, 1, 1, false}, EraDate{1457, 1, 20, false}, false},
+	{"明", "英宗朱祁镇", "天顺", 1457, EraDate{1457, 1, 21, false}, EraDate{1464, 12, 30, false}, false},
+	{"明", "宪宗朱见深", "成化", 1465, EraDate{1465, 1, 1, false}, EraDate{1487, 12, 30, false}, false},
+	{"明", "孝宗朱祐樘", "弘治", 1488, EraDate{1488, 1, 1, false}, EraDate{1505, 12, 30, false}, false},
+	{"明", "武宗朱厚照", "正德", 1506, EraDate{1506, 1, 1, false}, EraDate{1521, 12, 30, false}, false},
+	{"明", "世宗朱厚熜", "嘉靖", 1522, EraDate{1522, 1, 1, false}, EraDate{1566, 12, 30, false}, false},
+	{"明", "穆宗朱载坖", "隆庆", 1567, EraDate{1567, 1, 1, false}, EraDate{1572, 12, 30, false}, false},
+	{"明", "神宗朱翊钧", "万历", 1573, EraDate{1573, 1, 1, false}, EraDate{1620, 7, 30, false}, false},
+	{"明", "光宗朱常洛", "泰昌", 1620, EraDate{1620, 8, 1, false}, EraDate{1620, 12, 30, false}, false},
+	{"后金", "太祖努尔哈赤", "天命", 1616, EraDate{1616, 1, 1, false}, EraDate{1626, 12, 30, false}, false},
+	{"明", "熹宗朱由校", "天启", 1621, EraDate{1621, 1, 1, false}, EraDate{1627, 12, 30, false}, false},
+	{"后金", "太宗皇太极", "天聪", 1627, EraDate{1627, 1, 1, false}, EraDate{1636, 4, 10, false}, false},
+	{"明", "思宗朱由检", "崇祯", 1628, EraDate{1628, 1, 1, false}, EraDate{1644, 3, 19, false}, false},
+	{"清", "太宗皇太极", "崇德", 1636, EraDate{1636, 4, 11, false}, EraDate{1643, 12, 30, false}, false},
+	{"清", "世祖福临", "顺治", 1644, EraDate{1644, 1, 1, false}, EraDate{1661, 12, 30, false}, false},
+	{"大顺", "李自成", "永昌", 1644, EraDate{1644, 1, 1, false}, EraDate{1645, 5, 30, false}, false},
+	{"南明", "安宗朱由崧", "崇祯", 1628, EraDate{1644, 3, 20, false}, EraDate{1644, 12, 30, false}, false},
+	{"大西", "张献忠", "大顺", 1644, EraDate{1644, 11, 16, false}, EraDate{1646, 11, 27, false}, false},
+	{"南明", "安宗朱由崧", "弘光", 1645, EraDate{1645, 1, 1, false}, EraDate{1645, 5, 15, false}, false},
+	{"南明", "绍宗朱聿键", "隆武", 1645, EraDate{1645, 7, 1, false}, EraDate{1646, 8, 28, false}, false},
+	{"南明", "昭宗朱由榔", "永历", 1647, EraDate{1647, 1, 1, false}, EraDate{1662, 4, 30, false}, false},
+	{"清", "圣祖玄烨", "康熙", 1662, EraDate{1662, 1, 1, false}, EraDate{1722, 12, 30, false}, false},
+	{"明郑", "延平王郑氏", "永历", 1647, EraDate{1662, 5, 1, false}, EraDate{1683, 8, 30, false}, false},
+	{"吴周", "吴三桂", "昭武", 1678, EraDate{1678, 3, 1, false}, EraDate{1678, 12, 30, false}, false},
+	{"吴周", "吴世璠", "洪化", 1679, EraDate{1679, 1, 1, false}, EraDate{1681, 10, 28, false}, false},
+	{"清", "世宗胤禛", "雍正", 1723, EraDate{1723, 1, 1, false}, EraDate{1735, 12, 30, false}, false},
+	{"清", "高宗弘历", "乾隆", 1736, EraDate{1736, 1, 1, false}, EraDate{1795, 12, 30, false}, false},
+	{"清", "仁宗颙琰", "嘉庆", 1796, EraDate{1796, 1, 1, false}, EraDate{1820, 12, 30, false}, false},
+	{"清", "宣宗旻宁", "道光", 1821, EraDate{1821, 1, 1, false}, EraDate{1850, 12, 30, false}, false},
+	{"清", "文宗奕詝", "咸丰", 1851, EraDate{1851, 1, 1, false}, EraDate{1861, 12, 30, false}, false},
+	{"清", "穆宗载淳", "同治", 1862, EraDate{1862, 1, 1, false}, EraDate{1874, 12, 30, false}, false},
+	{"清", "德宗载湉", "光绪", 1875, EraDate{1875, 1, 1, false}, EraDate{1908, 12, 30, false}, false},
+	{"清", "溥仪", "宣统", 1909, EraDate{1909, 1, 1, false}, EraDate{1911, 12, 25, false}, false},
+	{"中华民国", "", "民国", 1912, EraDate{1912, 1, 1, false}, EraDate{}, true},
+}
@@ -0,0 +1,324 @@
+package gocalendar
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// type Era struct 年号
+type Era struct {
+	Dynasty   string  // 朝代或政权,如明、南明、清
+	Emperor   string  // 皇帝
+	Name      string  // 年号
+	FirstYear int     // 元年所在的农历年,按公历纪年的为公历年
+	Start     EraDate // 起始日(含)
+	End       EraDate // 结束日(含),Year为0表示沿用至今
+	Gregorian bool    // 按公历纪年,如民国
+}
+
+// type EraDate struct 年号的起止日,农历日期,按公历纪年的年号为公历日期
+type EraDate struct {
+	Year  int  // 年
+	Month int  // 月
+	Day   int  // 日
+	Leap  bool // 是否农历闰月
+}
+
+// type EraYear struct 年号纪年
+type EraYear struct {
+	Dynasty string `json:"dynasty"` // 朝代或政权
+	Emperor string `json:"emperor"` // 皇帝
+	Name    string `json:"name"`    // 年号
+	Year    int    `json:"year"`    // 年号的第几年
+}
+
+// (EraYear) String 年号纪年显示,如康熙六十一年,朝代可用Dynasty另加
+func (ey EraYear) String() string {
+	return ey.Name + EraYearChinese(ey.Year) + "年"
+}
+
+// EraYearChinese 年号纪年的年数汉字表示法,第一年为元
+func EraYearChinese(n int) string {
+	if n == 1 {
+		return "元"
+	}
+	if n < 1 || n > 999 {
+		return strconv.Itoa(n)
+	}
+
+	s := ""
+	h, t, u := n/100, n/10%10, n%10
+	if h > 0 {
+		s = lunarNumberArray[h] + "百"
+		if t == 0 && u > 0 {
+			s += "零"
+		}
+	}
+	if t > 0 {
+		if t > 1 || h > 0 {
+			s += lunarNumberArray[t]
+		}
+		s += lunarNumberArray[10]
+	}
+	if u > 0 {
+		s += lunarNumberArray[u]
+	}
+	return s
+}
+
+// EraTable 内置年号表的副本,按起始日排列
+//
+// 收录明(1368年)以后的年号,包括南明、明郑、后金、大顺、大西、吴周等并存的政权,不含明以前的年号和太平天国。
+// 起止日为当时的农历日期,而历史农历(CalendarConfig.HistoricalLunar)除天启七年八月外没有按历书校对,
+// 明代的年号换算成公历时可能与历表相差一日
+func EraTable() []Era {
+	eras := make([]Era, len(eraTable))
+	copy(eras, eraTable)
+	return eras
+}
+
+// eraDateKey 用于比较先后的日期序号,闰月排在同月之后
+func eraDateKey(year, month, day int, leap bool) int {
+	k := (year*13 + month) * 2
+	if leap {
+		k++
+	}
+	return k*32 + day
+}
+
+// (Era) contains 日期是否在该年号行用期间
+func (e Era) contains(year, month, day int, leap bool) bool {
+	k := eraDateKey(year, month, day, leap)
+	if k < eraDateKey(e.Start.Year, e.Start.Month, e.Start.Day, e.Start.Leap) {
+		return false
+	}
+	return e.End.Year == 0 || k <= eraDateKey(e.End.Year, e.End.Month, e.End.Day, e.End.Leap)
+}
+
+// erasOf 农历日期和公历日期所在的年号,并存的政权各返回一个
+func erasOf(lunarYear, lunarMonth, lunarDay int, isLeap bool, t time.Time) []EraYear {
+	var eys []EraYear
+	for _, e := range eraTable {
+		year := lunarYear
+		if e.Gregorian {
+			if t.IsZero() {
+				continue
+			}
+			year = t.Year()
+			if !e.contains(year, int(t.Month()), t.Day(), false) {
+				continue
+			}
+		} else if !e.contains(lunarYear, lunarMonth, lunarDay, isLeap) {
+			continue
+		}
+		eys = append(eys, EraYear{Dynasty: e.Dynasty, Emperor: e.Emperor, Name: e.Name, Year: year - e.FirstYear + 1})
+	}
+	return eys
+}
+
+// (*Calendar) Eras 公历时间t所在的年号纪年
+//
+// 按t当日的农历查年号表,同一日有并存的政权时各返回一个,如1650年有清顺治七年和南明永历四年。
+// 年号表只收录明(1368年)以后的年号,更早的日期返回nil,见EraTable。
+// 明代的日期建议设置CalendarConfig.HistoricalLunar,按当时的历法推算农历(没有逐月校对,见EraTable)
+func (c *Calendar) Eras(t time.Time) []EraYear {
+	t = t.In(c.loc)
+	lunarYear, lunarMonth, lunarDay, isLeap, ok := c.tableGregorianToLunar(t.Year(), int(t.Month()), t.Day())
+	if !ok {
+		lunarYear, lunarMonth, lunarDay, isLeap, _ = c.astroGregorianToLunar(t)
+	}
+	return erasOf(lunarYear, lunarMonth, lunarDay, isLeap, t)
+}
+
+// (*Calendar) LunarDateEras 农历日期ld所在的年号纪年
+//
+// 按公历纪年的年号(民国)需将ld转为公历,ld不合法时只返回按农历纪年的年号。
+// 是否闰月按ld.LeapStr判断
+func (c *Calendar) LunarDateEras(ld LunarDate) []EraYear {
+	isLeap := ld.LeapStr != ""
+	g, err := c.LunarToGregorian(ld.Year, ld.Month, ld.Day, isLeap)
+	if err != nil {
+		g = time.Time{}
+	}
+	return erasOf(ld.Year, ld.Month, ld.Day, isLeap, g)
+}
+
+// (*Calendar) ParseEraDate 将年号纪年的日期转为公历
+//
+// 如乾隆三年五月初五为1738年农历五月初五。年号前可带朝代,如清乾隆三年;年数可用元、汉字或阿拉伯数字;月份可用正、冬、腊及闰;
+// 日可用初五、廿一或五日等写法。只有年时为该年号该年的第一天。
+// 农历日期用LunarToGregorian转换,民国按公历。
+// 年号表只收录明(1368年)以后的年号,明以前的年号(如贞观)和太平天国的纪年返回ErrUnknownEra。
+// 日期不在年号行用期间或不合法时返回*DateError,其中的年份为农历年(民国为公历年)
+//
+// @param string s 年号纪年的日期
+func (c *Calendar) ParseEraDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	// 可能有同名的年号,如明郑沿用南明的永历;年号也可能与朝代同名,如大西的大顺。
+	// 去掉了朝代前缀的匹配优先,如大顺永昌元年只匹配大顺的永昌,不匹配大西的大顺
+	var eras []Era
+	var rests []string
+	prefixed := false
+	for _, e := range eraTable {
+		r := s
+		p := e.Dynasty != "" && strings.HasPrefix(s, e.Dynasty)
+		if p {
+			r = s[len(e.Dynasty):]
+		}
+		if !strings.HasPrefix(r, e.Name) || (prefixed && !p) {
+			continue
+		}
+		if p && !prefixed {
+			prefixed = true
+			eras, rests = eras[:0], rests[:0]
+		}
+		eras = append(eras, e)
+		rests = append(rests, r[len(e.Name):])
+	}
+	if len(eras) == 0 {
+		return time.Time{}, ErrUnknownEra
+	}
+
+	var dateErr error
+	for i, e := range eras {
+		n, month, day, isLeap, ok := parseEraDateRest(rests[i])
+		year := 0
+		if n > 0 {
+			year = e.FirstYear + n - 1
+		}
+		if !ok {
+			if dateErr == nil {
+				dateErr = &DateError{Op: "ParseEraDate", Year: year, Month: month, Day: day, Leap: isLeap, Err: ErrInvalidDate}
+			}
+			continue
+		}
+
+		m, d, leap := month, day, isLeap
+		if m == 0 {
+			// 只有年,取该年号该年的第一天
+			m, d = 1, 1
+			if year == e.Start.Year {
+				m, d, leap = e.Start.Month, e.Start.Day, e.Start.Leap
+			}
+		}
+		if !e.contains(year, m, d, leap) || (e.Gregorian && leap) {
+			if dateErr == nil {
+				dateErr = &DateError{Op: "ParseEraDate", Year: year, Month: month, Day: day, Leap: isLeap, Err: ErrInvalidDate}
+			}
+			continue
+		}
+
+		if e.Gregorian {
+			if err := checkGregorianDate(year, m, d); err != nil {
+				return time.Time{}, &DateError{Op: "ParseEraDate", Year: year, Month: m, Day: d, Err: err}
+			}
+			return time.Date(year, time.Month(m), d, 0, 0, 0, 0, c.loc), nil
+		}
+		return c.LunarToGregorian(year, m, d, leap)
+	}
+
+	return time.Time{}, dateErr
+}
+
+// parseEraDateRest 解析年号之后的年月日,如三年闰五月初五,没有月日时month和day为0
+func parseEraDateRest(s string) (n, month, day int, isLeap, ok bool) {
+	i := strings.Index(s, "年")
+	if i < 0 {
+		return
+	}
+	if s[:i] == "元" {
+		n = 1
+	} else if n, ok = parseChineseNumber(s[:i]); !ok || n < 1 {
+		return 0, 0, 0, false, false
+	}
+	s = s[i+len("年"):]
+	if s == "" {
+		return n, 0, 0, false, true
+	}
+
+	// 月
+	for _, p := range []string{"闰", "閏"} {
+		if strings.HasPrefix(s, p) {
+			isLeap = true
+			s = s[len(p):]
+		}
+	}
+	i = strings.Index(s, "月")
+	if i < 0 {
+		return n, 0, 0, isLeap, false
+	}
+	switch ms := s[:i]; ms {
+	case "正":
+		month = 1
+	case "冬":
+		month = 11
+	case "腊", "臘":
+		month = 12
+	default:
+		if month, ok = parseChineseNumber(ms); !ok || month < 1 || month > 12 {
+			return n, 0, 0, isLeap, false
+		}
+	}
+	s = s[i+len("月"):]
+
+	// 日
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "初"), "日")
+	if day, ok = parseChineseNumber(s); !ok || day < 1 || day > 31 {
+		return n, month, 0, isLeap, false
+	}
+	return n, month, day, isLeap, true
+}
+
+// parseChineseNumber 解析汉字或阿拉伯数字,如六十一、一百零五、廿一、113
+func parseChineseNumber(s string) (int, bool) {
+	if s == "" {
+		return 0, false
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, true
+	}
+
+	total, digit := 0, -1
+	for s != "" {
+		r, size := utf8.DecodeRuneInString(s)
+		s = s[size:]
+		switch r {
+		case '十', '百':
+			unit := 10
+			if r == '百' {
+				unit = 100
+			}
+			if digit < 0 {
+				digit = 1
+			}
+			total += digit * unit
+			digit = -1
+		case '廿':
+			total += 20
+		case '卅':
+			total += 30
+		default:
+			d := strings.IndexRune("〇一二三四五六七八九", r)
+			if d < 0 {
+				if r != '零' {
+					return 0, false
+				}
+				d = 0
+			} else {
+				d = utf8.RuneCountInString("〇一二三四五六七八九"[:d])
+			}
+			if digit < 0 {
+				digit = d
+			} else {
+				digit = digit*10 + d
+			}
+		}
+	}
+	if digit > 0 {
+		total += digit
+	}
+	return total, true
+}
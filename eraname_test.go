@@ -0,0 +1,156 @@
+package gocalendar
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEraYearChinese(t *testing.T) {
+	tests := map[int]string{1: "元", 2: "二", 10: "十", 13: "十三", 20: "二十", 61: "六十一", 100: "一百", 105: "一百零五", 113: "一百一十三"}
+	for n, want := range tests {
+		s := EraYearChinese(n)
+		if v, ok := parseChineseNumber(s); s == want && (n == 1 || ok && v == n) {
+			t.Log("passed")
+		} else {
+			t.Error(n, s, v)
+		}
+	}
+}
+
+func TestCalendar_Eras(t *testing.T) {
+	c := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai"})
+
+	tests := []struct {
+		y, m, d int
+		want    []string
+	}{
+		{1722, 12, 20, []string{"康熙六十一年"}},
+		{1650, 6, 1, []string{"顺治七年", "永历四年"}},          // 清与南明并存
+		{1636, 3, 1, []string{"天聪十年", "崇祯九年"}},          // 后金与明并存
+		{1402, 9, 1, []string{"洪武三十五年"}},                // 成祖即位后革除建文年号
+		{1644, 6, 1, []string{"顺治元年", "永昌元年", "崇祯十七年"}}, // 清、大顺、南明并存
+		{1645, 3, 1, []string{"顺治二年", "永昌二年", "大顺二年", "弘光元年"}},
+		{1680, 1, 1, []string{"康熙十八年", "永历三十三年", "洪化元年"}}, // 清、明郑、吴周并存
+		{1912, 1, 20, []string{"宣统三年", "民国元年"}},           // 清帝退位前
+		{2024, 5, 1, []string{"民国一百一十三年"}},
+		{1300, 1, 1, nil},
+	}
+	for _, tt := range tests {
+		eys := c.Eras(time.Date(tt.y, time.Month(tt.m), tt.d, 0, 0, 0, 0, c.loc))
+		ok := len(eys) == len(tt.want)
+		for i := 0; ok && i < len(eys); i++ {
+			ok = eys[i].String() == tt.want[i]
+		}
+		if ok {
+			t.Log("passed")
+		} else {
+			t.Error(tt, eys)
+		}
+	}
+
+	// 农历日期查年号,附在LunarDate上
+	e := NewCalendar(CalendarConfig{Grid: GridDay, TimeZoneName: "Asia/Shanghai", Lunar: true, EraNames: true})
+	ld := e.GregorianToLunar(1738, 6, 21)
+	if eys := e.LunarDateEras(ld); len(eys) == 1 && eys[0].Dynasty == "清" && eys[0].Emperor == "高宗弘历" && eys[0].String() == "乾隆三年" &&
+		len(ld.Eras) == 1 && ld.Eras[0] == eys[0] {
+		t.Log("passed")
+	} else {
+		t.Error(eys, ld.Eras)
+	}
+	items := e.GenerateWithDate(1912, 1, 20)
+	if eys := items[0].LunarDate.Eras; len(eys) == 2 && eys[1].Name == "民国" && eys[1].Year == 1 {
+		t.Log("passed")
+	} else {
+		t.Error(eys)
+	}
+	if ld := c.GregorianToLunar(1738, 6, 21); ld.Eras == nil {
+		t.Log("passed")
+	} else {
+		t.Error(ld.Eras)
+	}
+
+	// 2033年闰十一月,YearLeapMonth为11的平月十一月二十在2033年,闰十一月二十在2034年
+	if eys := e.LunarDateEras(LunarDate{Year: 2033, Month: 11, Day: 20, YearLeapMonth: 11}); len(eys) == 1 && eys[0].Year == 122 {
+		t.Log("passed")
+	} else {
+		t.Error(eys)
+	}
+	if eys := e.LunarDateEras(LunarDate{Year: 2033, Month: 11, Day: 20, YearLeapMonth: 11, LeapStr: "闰"}); len(eys) == 1 && eys[0].Year == 123 {
+		t.Log("passed")
+	} else {
+		t.Error(eys)
+	}
+}
+
+func TestCalendar_ParseEraDate(t *testing.T) {
+	c := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai"})
+
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"乾隆三年五月初五", "1738-06-21"},
+		{"清乾隆3年5月5日", "1738-06-21"},
+		{"康熙六十一年十一月十三", "1722-12-20"},
+		{"光绪三十四年十月廿一", "1908-11-14"},
+		{"崇德元年", "1636-05-15"},         // 天聪十年四月十一改元
+		{"明郑永历三十七年正月初一", "1683-01-27"}, // 南明亡后明郑沿用永历
+		{"民国三年五月五日", "1914-05-05"},
+		{"昭武元年三月初一", "1678-03-23"}, // 吴三桂称帝
+		{"大西大顺元年十一月十六", "1644-12-14"},
+		{"大顺永昌元年三月初一", "1644-04-07"}, // 大西的年号大顺与大顺政权同名
+		{"永昌元年三月初一", "1644-04-07"},
+	}
+	for _, tt := range tests {
+		g, err := c.ParseEraDate(tt.s)
+		if err == nil && g.Format("2006-01-02") == tt.want {
+			t.Log("passed")
+		} else {
+			t.Error(tt, g, err)
+		}
+	}
+
+	// 闰月
+	g, err := c.ParseEraDate("光绪三十四年闰正月初一")
+	if errors.Is(err, ErrNoLeapMonth) {
+		t.Log("passed")
+	} else {
+		t.Error(g, err)
+	}
+	want, _ := c.LunarToGregorian(1737, 9, 1, true)
+	if g, err := c.ParseEraDate("乾隆二年闰九月初一"); c.LunarLeap(1737) == 9 && err == nil && g.Equal(want) {
+		t.Log("passed")
+	} else {
+		t.Error(g, err)
+	}
+
+	var de *DateError
+	// 年号表只收录明以后的年号
+	if _, err := c.ParseEraDate("贞观元年"); errors.Is(err, ErrUnknownEra) {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+	if _, err := c.ParseEraDate("天宝三年"); errors.Is(err, ErrUnknownEra) {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+	if _, err := c.ParseEraDate("乾隆六十一年"); errors.As(err, &de) && errors.Is(err, ErrInvalidDate) && de.Year == 1796 {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+	if _, err := c.ParseEraDate("天聪十年五月初一"); errors.Is(err, ErrInvalidDate) {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+	// DateError的年份为农历年,不是年号的第几年
+	if _, err := c.ParseEraDate("乾隆三年十三月初一"); errors.As(err, &de) && errors.Is(err, ErrInvalidDate) && de.Year == 1738 {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+}
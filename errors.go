@@ -0,0 +1,196 @@
+package gocalendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// 计算结果可靠的公历年份范围
+const (
+	MinYear = -1000
+	MaxYear = 3000
+)
+
+// 可用errors.Is判断的错误
+var (
+	ErrOutOfRange    = errors.New("年份超出计算范围")  // 年份不在MinYear至MaxYear之间
+	ErrNoLeapMonth   = errors.New("该月不是该年的闰月") // 指定的闰月在该年不存在
+	ErrDayOutOfMonth = errors.New("日期超出该月的天数") // 农历日大于该月的天数
+	ErrInvalidDate   = errors.New("日期错误")      // 月份或日期不合法
+	ErrUnknownEra    = errors.New("未知的年号")     // 年号表中没有该年号
+	ErrNoLocation    = errors.New("未设置地理位置")   // 需要地理位置的计算未设置CalendarConfig.LocationSet
+)
+
+// type DateError struct 带出错日期的错误
+//
+// Err为上面的错误之一,可用errors.Is(err, ErrOutOfRange)等判断
+type DateError struct {
+	Op    string // 出错的方法
+	Year  int    // 年
+	Month int    // 月
+	Day   int    // 日
+	Leap  bool   // 是否农历闰月
+	Err   error  // 错误原因
+}
+
+// (*DateError) Error 错误信息
+func (e *DateError) Error() string {
+	leap := ""
+	if e.Leap {
+		leap = lunarLeapString
+	}
+	return fmt.Sprintf("gocalendar: %s %d-%s%d-%d: %s", e.Op, e.Year, leap, e.Month, e.Day, e.Err.Error())
+}
+
+// (*DateError) Unwrap 错误原因
+func (e *DateError) Unwrap() error {
+	return e.Err
+}
+
+// checkYear 公历年份是否在计算范围内
+func checkYear(year int) error {
+	if year < MinYear || year > MaxYear {
+		return ErrOutOfRange
+	}
+	return nil
+}
+
+// checkLunarYear 农历年份是否在计算范围内,农历年的年末在下一个公历年
+func checkLunarYear(lunarYear int) error {
+	if lunarYear < MinYear || lunarYear >= MaxYear {
+		return ErrOutOfRange
+	}
+	return nil
+}
+
+// checkGregorianDate 公历日期是否合法且在计算范围内
+func checkGregorianDate(year, month, day int) error {
+	if month < 1 || month > 12 || day < 1 || day > GregorianMonthDays(year, month) {
+		return ErrInvalidDate
+	}
+	return checkYear(year)
+}
+
+// checkLunarDate 农历日期是否合法且在计算范围内
+func checkLunarDate(lunarYear, lunarMonth, lunarDay int) error {
+	if lunarMonth < 1 || lunarMonth > 12 || lunarDay < 1 || lunarDay > 30 {
+		return ErrInvalidDate
+	}
+	return checkLunarYear(lunarYear)
+}
+
+// (*Calendar) GregorianToLunarE 公历转农历,检查日期
+//
+// 日期不合法时返回ErrInvalidDate,超出计算范围或计算用到的Delta T超出有效范围时返回ErrOutOfRange,ctx已取消时返回ctx.Err()
+func (c *Calendar) GregorianToLunarE(ctx context.Context, year, month, day int) (LunarDate, error) {
+	if err := ctx.Err(); err != nil {
+		return LunarDate{}, err
+	}
+	err := checkGregorianDate(year, month, day)
+	var ld LunarDate
+	if err == nil {
+		ld, err = c.gregorianToLunar(time.Date(year, time.Month(month), day, 0, 0, 0, 0, c.loc), false)
+	}
+	if err != nil {
+		return LunarDate{}, &DateError{Op: "GregorianToLunar", Year: year, Month: month, Day: day, Err: err}
+	}
+
+	return ld, nil
+}
+
+// (*Calendar) LunarToGregorianE 农历转公历,检查日期
+//
+// 除GregorianToLunarE的错误外,该年没有指定的闰月时返回ErrNoLeapMonth,农历日大于该月天数时返回ErrDayOutOfMonth。
+// 按天文算法计算的年份,用到的Delta T超出有效范围时LunarToGregorian也返回ErrOutOfRange
+func (c *Calendar) LunarToGregorianE(ctx context.Context, lunarYear, lunarMonth, lunarDay int, isLeap bool) (time.Time, error) {
+	if err := ctx.Err(); err != nil {
+		return time.Time{}, err
+	}
+
+	err := checkLunarDate(lunarYear, lunarMonth, lunarDay)
+	var t time.Time
+	if err == nil {
+		t, err = c.LunarToGregorian(lunarYear, lunarMonth, lunarDay, isLeap)
+	}
+	if err != nil {
+		return time.Time{}, &DateError{Op: "LunarToGregorian", Year: lunarYear, Month: lunarMonth, Day: lunarDay, Leap: isLeap, Err: err}
+	}
+
+	return t, nil
+}
+
+// (*Calendar) LunarMonthDaysE 农历某个月的天数,检查日期
+//
+// 错误同LunarToGregorianE
+func (c *Calendar) LunarMonthDaysE(ctx context.Context, lunarYear, lunarMonth int, isLeap bool) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	err := checkLunarDate(lunarYear, lunarMonth, 1)
+	var days int
+	if err == nil {
+		days, err = c.LunarMonthDays(lunarYear, lunarMonth, isLeap)
+	}
+	if err != nil {
+		return 0, &DateError{Op: "LunarMonthDays", Year: lunarYear, Month: lunarMonth, Leap: isLeap, Err: err}
+	}
+
+	return days, nil
+}
+
+// (*Calendar) SolarTermsE 一整年的节气,检查年份
+//
+// 超出计算范围或计算用到的Delta T超出有效范围时返回ErrOutOfRange
+func (c *Calendar) SolarTermsE(ctx context.Context, year int) ([]*SolarTermItem, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	err := checkYear(year)
+	if err == nil {
+		_, err = c.solarTerms(year)
+	}
+	if err != nil {
+		return nil, &DateError{Op: "SolarTerms", Year: year, Err: err}
+	}
+
+	return c.SolarTerms(year), nil
+}
+
+// (*Calendar) ChineseSexagenaryCycleE 日期时间对应的干支,检查年份
+//
+// 超出计算范围或计算用到的Delta T超出有效范围时返回ErrOutOfRange,
+// 设置了TrueSolarTime而未设置LocationSet时返回ErrNoLocation
+func (c *Calendar) ChineseSexagenaryCycleE(ctx context.Context, t time.Time) (GZ, error) {
+	if err := ctx.Err(); err != nil {
+		return GZ{}, err
+	}
+	err := checkYear(t.Year())
+	var gzs GZ
+	if err == nil {
+		gzs, err = c.chineseSexagenaryCycle(t)
+	}
+	if err != nil {
+		return GZ{}, &DateError{Op: "ChineseSexagenaryCycle", Year: t.Year(), Month: int(t.Month()), Day: t.Day(), Err: err}
+	}
+
+	return gzs, nil
+}
+
+// (*Calendar) LunarOccurrencesE 农历周年事件在[start, end]内的公历日期,检查年份
+//
+// 逐年计算时检查ctx,已取消则返回ctx.Err()
+func (c *Calendar) LunarOccurrencesE(ctx context.Context, r LunarRecurrence, start, end time.Time) ([]time.Time, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	for _, t := range []time.Time{start, end} {
+		if err := checkYear(t.Year()); err != nil {
+			return nil, &DateError{Op: "LunarOccurrences", Year: t.Year(), Month: int(t.Month()), Day: t.Day(), Err: err}
+		}
+	}
+
+	return c.lunarOccurrences(ctx, r, start, end)
+}
@@ -0,0 +1,152 @@
+package gocalendar
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCalendar_GregorianToLunarE(t *testing.T) {
+	c := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai"})
+	ctx := context.Background()
+
+	ld, err := c.GregorianToLunarE(ctx, 2021, 5, 6)
+	if err == nil && ld.Month == 3 && ld.Day == 25 {
+		t.Log("passed")
+	} else {
+		t.Error(ld, err)
+	}
+
+	if _, err := c.GregorianToLunarE(ctx, 2021, 2, 29); errors.Is(err, ErrInvalidDate) {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+
+	_, err = c.GregorianToLunarE(ctx, 3001, 1, 1)
+	var de *DateError
+	if errors.Is(err, ErrOutOfRange) && errors.As(err, &de) && de.Year == 3001 {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := c.GregorianToLunarE(cctx, 2021, 5, 6); err == context.Canceled {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+}
+
+func TestCalendar_LunarToGregorianE(t *testing.T) {
+	c := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai"})
+	ctx := context.Background()
+
+	gd, err := c.LunarToGregorianE(ctx, 2020, 4, 14, true)
+	if err == nil && gd.Format("2006-01-02") == "2020-06-05" {
+		t.Log("passed")
+	} else {
+		t.Error(gd, err)
+	}
+
+	// 2021年没有闰月
+	if _, err := c.LunarToGregorianE(ctx, 2021, 4, 14, true); errors.Is(err, ErrNoLeapMonth) {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+
+	// 2021年腊月只有29天
+	if _, err := c.LunarToGregorianE(ctx, 2021, 12, 30, false); errors.Is(err, ErrDayOutOfMonth) {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+
+	if _, err := c.LunarToGregorianE(ctx, 2021, 13, 1, false); errors.Is(err, ErrInvalidDate) {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+
+	if _, err := c.LunarMonthDaysE(ctx, 2021, 4, true); errors.Is(err, ErrNoLeapMonth) {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+}
+
+func TestCalendar_SolarTermsE(t *testing.T) {
+	c := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai"})
+	ctx := context.Background()
+
+	sts, err := c.SolarTermsE(ctx, 2021)
+	if err == nil && len(sts) == 26 {
+		t.Log("passed")
+	} else {
+		t.Error(len(sts), err)
+	}
+
+	if _, err := c.SolarTermsE(ctx, -1001); errors.Is(err, ErrOutOfRange) {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+
+	if _, _, err := StarSign(13, 1); err == ErrInvalidDate {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+	for _, md := range [][2]int{{2, 30}, {2, 31}, {4, 31}, {6, 31}, {9, 31}, {11, 31}} {
+		if _, _, err := StarSign(md[0], md[1]); err != ErrInvalidDate {
+			t.Error(md, err)
+		}
+	}
+	if i, _, err := StarSign(2, 29); err == nil && i == 1 {
+		t.Log("passed")
+	} else {
+		t.Error(i, err)
+	}
+}
+
+// 计算用到的Delta T超出有效范围时返回错误,不按Delta T为0计算
+func TestCalendar_DeltaTError(t *testing.T) {
+	c := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai"})
+
+	if _, err := c.solarTerms(3100); errors.Is(err, ErrOutOfRange) {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+	if _, err := c.chineseSexagenaryCycle(time.Date(3100, 6, 1, 0, 0, 0, 0, c.loc)); errors.Is(err, ErrOutOfRange) {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+	if _, err := c.gregorianToLunar(time.Date(3100, 6, 1, 0, 0, 0, 0, c.loc), false); errors.Is(err, ErrOutOfRange) {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+	if _, err := c.LunarToGregorian(3100, 5, 1, false); errors.Is(err, ErrOutOfRange) {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+	if _, err := c.LunarMonthDays(3100, 5, false); errors.Is(err, ErrOutOfRange) {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+
+	// 范围内的年份不受影响
+	if _, err := c.solarTerms(MaxYear); err == nil {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,273 @@
+package gocalendar
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// FestivalKind 节日所属的历法
+type FestivalKind int
+
+const (
+	FestivalGregorian FestivalKind = iota // 公历节日
+	FestivalLunar                         // 农历节日
+	FestivalHijri                         // 伊斯兰历节日
+	FestivalHebrew                        // 犹太节日(希伯来历)
+	FestivalPersian                       // 波斯历节日
+)
+
+var (
+	// 公历节日索引正则,如2M14D表示2月14日
+	gregorianFestivalDayRegexp = regexp.MustCompile("^([0-9]{1,2})M([0-9]{1,2})D$")
+
+	// 公历节日索引正则,某月第几个周几,如5M2W0表示5月第2个周日
+	gregorianFestivalWeekRegexp = regexp.MustCompile("^([0-9]{1,2})M(?:D)?([1-4])W([0-6])$")
+
+	// 农历节日索引正则,如8M15D表示8月15日,12M$表示12月最后一日,5@M12D表示闰5月12日
+	// 整个索引都要匹配,旧版本只匹配索引的结尾,如x8M15D曾被当作8M15D
+	lunarFestivalRegexp = regexp.MustCompile("^([0-9]{1,2})(@?)M(?:([0-9]{1,2})D)?(\\$)?$")
+)
+
+// 默认节日表,由gregorianFestivalArray和lunarFestivalArray初始
+var defaultFestivalRegistry = newBuiltinFestivalRegistry()
+
+// type festivalEntry struct 节日表中的一条记录
+type festivalEntry struct {
+	names   string // 节日名称,多个节日用","分隔,节日名称前加"*"号表示重要且在日历表上显示
+	replace bool   // true则覆盖基础节日表中同一索引的节日,否则追加到基础节日表的节日之后
+}
+
+// type FestivalRegistry struct 节日表
+//
+// 节日表的索引沿用gregorianFestivalArray和lunarFestivalArray的写法,如"2M14D","5M2W0","12M$","5@M12D"。
+// 节日表可以以另一个节日表为基础(base)叠加增加、替换或删除节日,基础节日表中的修改会反映到叠加的节日表上,
+// 这样多个节日表可以共用同一个基础节日表
+type FestivalRegistry struct {
+	base    *FestivalRegistry
+	entries map[FestivalKind]map[string]festivalEntry
+	ver     uint64 // 修改次数,用于判断日历的节日缓存是否过期
+	mu      sync.RWMutex
+}
+
+// NewFestivalRegistry 以base为基础新建一个节日表
+//
+// base为nil时新建一个空的节日表
+func NewFestivalRegistry(base *FestivalRegistry) *FestivalRegistry {
+	return &FestivalRegistry{
+		base:    base,
+		entries: make(map[FestivalKind]map[string]festivalEntry),
+	}
+}
+
+// DefaultFestivalRegistry 默认节日表
+//
+// 未设置节日表的Calendar都使用该节日表(韩国和越南农历见LunarVariantFestivalRegistry),对它的修改会影响所有使用它的Calendar
+func DefaultFestivalRegistry() *FestivalRegistry {
+	return defaultFestivalRegistry
+}
+
+// newBuiltinFestivalRegistry 用内置的公历、农历、伊斯兰历和犹太节日新建节日表
+func newBuiltinFestivalRegistry() *FestivalRegistry {
+	r := NewFestivalRegistry(nil)
+	for k, v := range gregorianFestivalArray {
+		r.entries[FestivalGregorian] = setFestivalEntry(r.entries[FestivalGregorian], k, festivalEntry{names: v})
+	}
+	for k, v := range lunarFestivalArray {
+		r.entries[FestivalLunar] = setFestivalEntry(r.entries[FestivalLunar], k, festivalEntry{names: v})
+	}
+	for k, v := range hijriFestivalArray {
+		r.entries[FestivalHijri] = setFestivalEntry(r.entries[FestivalHijri], k, festivalEntry{names: v})
+	}
+	for k, v := range hebrewFestivalArray {
+		r.entries[FestivalHebrew] = setFestivalEntry(r.entries[FestivalHebrew], k, festivalEntry{names: v})
+	}
+	for k, v := range persianFestivalArray {
+		r.entries[FestivalPersian] = setFestivalEntry(r.entries[FestivalPersian], k, festivalEntry{names: v})
+	}
+	return r
+}
+
+// (*FestivalRegistry) Base 基础节日表
+func (r *FestivalRegistry) Base() *FestivalRegistry {
+	return r.base
+}
+
+// (*FestivalRegistry) Add 增加节日
+//
+// names为节日名称,多个节日用","分隔,节日名称前加"*"号表示重要且在日历表上显示,
+// 如果该索引已有节日,则names追加在原节日之后
+func (r *FestivalRegistry) Add(kind FestivalKind, key, names string) error {
+	if err := checkFestivalKey(kind, key); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.entries[kind][key]
+	if ok && e.names != "" {
+		e.names += "," + names
+	} else {
+		e.names = names
+	}
+	r.entries[kind] = setFestivalEntry(r.entries[kind], key, e)
+	r.ver++
+
+	return nil
+}
+
+// (*FestivalRegistry) Replace 替换节日
+//
+// 用names替换该索引原有的节日(包括基础节日表中的节日)
+func (r *FestivalRegistry) Replace(kind FestivalKind, key, names string) error {
+	if err := checkFestivalKey(kind, key); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries[kind] = setFestivalEntry(r.entries[kind], key, festivalEntry{names: names, replace: true})
+	r.ver++
+
+	return nil
+}
+
+// (*FestivalRegistry) Remove 删除节日
+//
+// 删除该索引的所有节日(包括基础节日表中的节日),索引的检查同Add
+func (r *FestivalRegistry) Remove(kind FestivalKind, key string) error {
+	if err := checkFestivalKey(kind, key); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries[kind] = setFestivalEntry(r.entries[kind], key, festivalEntry{replace: true})
+	r.ver++
+
+	return nil
+}
+
+// (*FestivalRegistry) Festivals 取出某历法的全部节日
+//
+// 返回的是合并了基础节日表后的一个新map,索引与值的写法同lunarFestivalArray
+func (r *FestivalRegistry) Festivals(kind FestivalKind) map[string]string {
+	var fm map[string]string
+	if r.base != nil {
+		fm = r.base.Festivals(kind)
+	} else {
+		fm = make(map[string]string)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for k, e := range r.entries[kind] {
+		if e.replace {
+			if e.names == "" {
+				delete(fm, k)
+			} else {
+				fm[k] = e.names
+			}
+			continue
+		}
+
+		if v, ok := fm[k]; ok && v != "" {
+			fm[k] = v + "," + e.names
+		} else {
+			fm[k] = e.names
+		}
+	}
+
+	return fm
+}
+
+// (*FestivalRegistry) version 节日表(包括基础节日表)的修改次数
+func (r *FestivalRegistry) version() uint64 {
+	r.mu.RLock()
+	v := r.ver
+	r.mu.RUnlock()
+
+	if r.base != nil {
+		v += r.base.version()
+	}
+
+	return v
+}
+
+// setFestivalEntry 写入一条节日记录,m为nil时新建
+func setFestivalEntry(m map[string]festivalEntry, key string, e festivalEntry) map[string]festivalEntry {
+	if m == nil {
+		m = make(map[string]festivalEntry)
+	}
+	m[key] = e
+	return m
+}
+
+// checkFestivalKey 检查节日索引的写法
+func checkFestivalKey(kind FestivalKind, key string) error {
+	switch kind {
+	case FestivalGregorian:
+		if gregorianFestivalDayRegexp.MatchString(key) || gregorianFestivalWeekRegexp.MatchString(key) {
+			return nil
+		}
+	case FestivalHijri, FestivalHebrew, FestivalPersian:
+		if gregorianFestivalDayRegexp.MatchString(key) {
+			return nil
+		}
+	case FestivalLunar:
+		if re := lunarFestivalRegexp.FindStringSubmatch(key); len(re) == 5 && (re[3] != "" || re[4] != "") {
+			return nil
+		}
+	default:
+		return errors.New("不支持的节日历法")
+	}
+
+	return errors.New("节日索引格式不正确")
+}
+
+// festivalItemFromNames 用节日名称列表整理出FestivalItem
+//
+// 节日名称前有"*"号的放入Show,其它的放入Secondary
+func festivalItemFromNames(names []string) FestivalItem {
+	var fi FestivalItem
+	for _, v := range names {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if svs := strings.Split(v, "*"); len(svs) > 1 {
+			fi.Show = append(fi.Show, svs[1])
+		} else {
+			fi.Secondary = append(fi.Secondary, v)
+		}
+	}
+	return fi
+}
+
+// (*Calendar) festivalRegistry 当前使用的节日表
+func (c *Calendar) festivalRegistry() *FestivalRegistry {
+	if c.config.Festivals != nil {
+		return c.config.Festivals
+	}
+	return LunarVariantFestivalRegistry(c.config.LunarVariant)
+}
+
+// (*Calendar) SetFestivalRegistry 设置节日表
+//
+// r为nil时使用默认节日表,设置后清除已缓存的节日
+func (c *Calendar) SetFestivalRegistry(r *FestivalRegistry) *Calendar {
+	c.config.Festivals = r
+	c.tempData.lFD = new(yearFestivalTemp)
+	c.tempData.gFD = new(yearFestivalTemp)
+	c.tempData.hFD = new(yearFestivalTemp)
+	c.tempData.jFD = new(yearFestivalTemp)
+	c.tempData.pFD = new(yearFestivalTemp)
+	c.Items = nil
+
+	return c
+}
@@ -0,0 +1,121 @@
+package gocalendar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFestivalRegistry(t *testing.T) {
+	base := NewFestivalRegistry(nil)
+	if err := base.Add(FestivalGregorian, "1M1D", "*元旦"); err != nil {
+		t.Error(err)
+	}
+	if err := base.Add(FestivalGregorian, "5M2W0", "*母亲节"); err != nil {
+		t.Error(err)
+	}
+
+	r := NewFestivalRegistry(base)
+	if err := r.Add(FestivalGregorian, "1M1D", "公司年会"); err != nil {
+		t.Error(err)
+	}
+	if err := r.Remove(FestivalGregorian, "5M2W0"); err != nil {
+		t.Error(err)
+	}
+	if err := r.Replace(FestivalLunar, "12M$", "*大年夜"); err != nil {
+		t.Error(err)
+	}
+
+	gfs := r.Festivals(FestivalGregorian)
+	if gfs["1M1D"] == "*元旦,公司年会" && len(gfs) == 1 {
+		t.Log("passed")
+	} else {
+		t.Error(gfs)
+	}
+
+	// 基础节日表的修改会反映到叠加的节日表
+	if err := base.Add(FestivalGregorian, "10M1D", "*国庆节"); err != nil {
+		t.Error(err)
+	}
+	if r.Festivals(FestivalGregorian)["10M1D"] == "*国庆节" {
+		t.Log("passed")
+	} else {
+		t.Error(r.Festivals(FestivalGregorian))
+	}
+
+	if r.Festivals(FestivalLunar)["12M$"] == "*大年夜" {
+		t.Log("passed")
+	} else {
+		t.Error(r.Festivals(FestivalLunar))
+	}
+}
+
+func TestFestivalRegistry_Key(t *testing.T) {
+	r := NewFestivalRegistry(nil)
+	for _, k := range []string{"1M", "13", "5M5W0", "x5M5D"} {
+		if err := r.Add(FestivalGregorian, k, "*x"); err == nil {
+			t.Error(k)
+		}
+	}
+	for _, k := range []string{"5M", "a5@M12D"} {
+		if err := r.Add(FestivalLunar, k, "*x"); err == nil {
+			t.Error(k)
+		}
+	}
+	for _, k := range []string{"5M12D", "12M$", "5@M12D"} {
+		if err := r.Add(FestivalLunar, k, "*x"); err != nil {
+			t.Error(k, err)
+		}
+	}
+
+	// 删除时同样检查索引,格式不正确的不记录,也不改变修改次数
+	v := r.version()
+	if err := r.Remove(FestivalLunar, "a5@M12D"); err != nil && r.version() == v && len(r.Festivals(FestivalLunar)) == 3 {
+		t.Log("passed")
+	} else {
+		t.Error(err, r.version(), r.Festivals(FestivalLunar))
+	}
+	if err := r.Remove(FestivalLunar, "5@M12D"); err == nil && r.version() == v+1 && len(r.Festivals(FestivalLunar)) == 2 {
+		t.Log("passed")
+	} else {
+		t.Error(err, r.version(), r.Festivals(FestivalLunar))
+	}
+}
+
+func TestCalendar_SetFestivalRegistry(t *testing.T) {
+	r := NewFestivalRegistry(DefaultFestivalRegistry())
+	c := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai", Lunar: true, Festivals: r})
+
+	tm := time.Date(2021, 3, 15, 0, 0, 0, 0, c.loc)
+	if fi := c.gregorianFestival(tm); len(fi.Secondary) == 1 && fi.Secondary[0] == "世界消费者权益日" {
+		t.Log("passed")
+	} else {
+		t.Error(fi)
+	}
+
+	// 修改节日表后缓存失效
+	if err := r.Replace(FestivalGregorian, "3M15D", "*消费者权益日"); err != nil {
+		t.Error(err)
+	}
+	if fi := c.gregorianFestival(tm); len(fi.Show) == 1 && fi.Show[0] == "消费者权益日" && len(fi.Secondary) == 0 {
+		t.Log("passed")
+	} else {
+		t.Error(fi)
+	}
+
+	// 农历节日
+	lr := NewFestivalRegistry(nil)
+	if err := lr.Add(FestivalLunar, "2M2D", "*龙抬头"); err != nil {
+		t.Error(err)
+	}
+	c.SetFestivalRegistry(lr)
+	if lf := c.lunarFestival(2021, 2, 2, false); len(lf.Show) == 1 && lf.Show[0] == "龙抬头" {
+		t.Log("passed")
+	} else {
+		t.Error(lf)
+	}
+	if lf := c.lunarFestival(2021, 1, 1, false); len(lf.Show) == 0 {
+		t.Log("passed")
+	} else {
+		t.Error(lf)
+	}
+}
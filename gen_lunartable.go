@@ -0,0 +1,63 @@
+//go:build ignore
+// +build ignore
+
+// 生成内置的农历表 lunartabledata.go
+//
+// 用法: go generate 或 go run gen_lunartable.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+
+	"github.com/liujiawm/gocalendar"
+)
+
+const (
+	startYear = 1900
+	endYear   = 2100
+)
+
+func main() {
+	data, err := gocalendar.BuildLunarTable(startYear, endYear)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen_lunartable.go; DO NOT EDIT.\n\n")
+	buf.WriteString("package gocalendar\n\n")
+	buf.WriteString("// 内置农历表覆盖的农历年份\n")
+	fmt.Fprintf(&buf, "const (\n\tlunarTableStartYear = %d\n\tlunarTableEndYear   = %d\n)\n\n", startYear, endYear)
+	buf.WriteString("// 内置农历表数据,每年3个字节,格式见cLunarTableYearBytes\n")
+	buf.WriteString("const lunarTableData = \"\" +\n")
+
+	// 每行10年
+	const perLine = 10 * 3
+	for i := 0; i < len(data); i += perLine {
+		j := i + perLine
+		if j > len(data) {
+			j = len(data)
+		}
+		buf.WriteString("\t\"")
+		for _, b := range data[i:j] {
+			fmt.Fprintf(&buf, "\\x%02x", b)
+		}
+		buf.WriteString("\"")
+		if j < len(data) {
+			buf.WriteString(" +")
+		}
+		buf.WriteString("\n")
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile("lunartabledata.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,284 @@
+package gocalendar
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 希伯来历月份,从尼散月起算,提斯利月为岁首;闰年有13个月,12月为第一亚达月,13月为第二亚达月
+const (
+	HebrewNisan      = iota + 1 // 尼散月
+	HebrewIyyar                 // 以珥月
+	HebrewSivan                 // 西弯月
+	HebrewTammuz                // 搭模斯月
+	HebrewAv                    // 埃波月
+	HebrewElul                  // 以禄月
+	HebrewTishrei               // 提斯利月
+	HebrewMarheshvan            // 玛西班月
+	HebrewKislev                // 基斯流月
+	HebrewTevet                 // 提别月
+	HebrewShevat                // 细罢特月
+	HebrewAdar                  // 亚达月,闰年为第一亚达月
+	HebrewAdarII                // 第二亚达月,只在闰年
+)
+
+// 希伯来历元年提斯利月1日(公元前3761年10月7日,儒略历)的儒略日数
+const cHebrewEpochJdn = 347998
+
+var (
+	// 希伯来历月份名称,尼散月为0,12为第二亚达月,13为闰年的第一亚达月
+	hebrewMonthNameArray = [14]string{"尼散月", "以珥月", "西弯月", "搭模斯月", "埃波月", "以禄月", "提斯利月",
+		"玛西班月", "基斯流月", "提别月", "细罢特月", "亚达月", "亚达二月", "亚达一月"}
+
+	// 犹太节日,索引写法同公历节日的"月M日D",月份从尼散月起算,如7M1D表示提斯利月1日,
+	// 12月的节日在闰年移到第二亚达月,节日名称前加"*"号表示重要且在日历表上显示
+	hebrewFestivalArray = map[string]string{"7M1D": "*犹太新年", "7M2D": "犹太新年", "7M10D": "*赎罪日", "7M15D": "*住棚节",
+		"7M22D": "圣会节", "7M23D": "诵经节", "9M25D": "*光明节", "11M15D": "树木新年", "12M14D": "*普珥节",
+		"1M15D": "*逾越节", "3M6D": "*七七节", "5M9D": "圣殿被毁日"}
+)
+
+// type HebrewDate struct 希伯来历日期
+//
+// 希伯来历的一日从前一天日落开始,这里按公历日期的白天对应
+type HebrewDate struct {
+	Year       int           `json:"year"`      // 年
+	Month      int           `json:"month"`     // 月,从尼散月起算,13为第二亚达月
+	Day        int           `json:"day"`       // 日
+	MonthName  string        `json:"monthName"` // 月份名称
+	MonthDays  int           `json:"monthDays"` // 该月天数,29或30
+	IsLeapYear bool          `json:"leap"`      // 该年是否闰年(13个月)
+	Festival   *FestivalItem `json:"festival"`  // 犹太节日
+
+	locale *Locale // 显示用的语言
+}
+
+// (HebrewDate) String 希伯来历日期显示
+func (hd HebrewDate) String() string {
+	return fmt.Sprintf(hd.locale.orDefault().HebrewDateFormat, hd.Year, hd.MonthName, hd.Day)
+}
+
+// GregorianToHebrew 公历转希伯来历
+//
+// 与农历相同,1582年10月15日之前的日期按儒略历
+func GregorianToHebrew(year, month, day int) (hebrewYear, hebrewMonth, hebrewDay int) {
+	return hebrewFromJdn(jdnOfDate(year, month, day))
+}
+
+// HebrewToGregorian 希伯来历转公历,返回loc时区该日0时
+//
+// 月份或日期不合法时返回ErrInvalidDate,平年指定第二亚达月时返回ErrNoLeapMonth,
+// 日期大于该月天数时返回ErrDayOutOfMonth,超出计算范围时返回ErrOutOfRange
+func HebrewToGregorian(hebrewYear, hebrewMonth, hebrewDay int, loc *time.Location) (time.Time, error) {
+	var err error
+	switch {
+	case hebrewYear < 1 || hebrewMonth < 1 || hebrewMonth > HebrewAdarII || hebrewDay < 1 || hebrewDay > 30:
+		err = ErrInvalidDate
+	case hebrewMonth > hebrewLastMonth(hebrewYear):
+		err = ErrNoLeapMonth
+	case hebrewDay > hebrewMonthDays(hebrewYear, hebrewMonth):
+		err = ErrDayOutOfMonth
+	default:
+		err = checkYear(hebrewYear - 3760)
+	}
+	if err != nil {
+		return time.Time{}, &DateError{Op: "HebrewToGregorian", Year: hebrewYear, Month: hebrewMonth, Day: hebrewDay, Err: err}
+	}
+
+	return lunarTableTime(hebrewJdn(hebrewYear, hebrewMonth, hebrewDay), loc), nil
+}
+
+// 以下算法摘自Edward M. Reingold, Nachum Dershowitz《Calendrical Calculations》第8章 The Hebrew Calendar
+
+// hebrewLeapYear 19年7闰,第3、6、8、11、14、17、19年为闰年
+func hebrewLeapYear(hebrewYear int) bool {
+	return floorMod(7*hebrewYear+1, 19) < 7
+}
+
+// hebrewLastMonth 该年最后一个月,闰年为13
+func hebrewLastMonth(hebrewYear int) int {
+	if hebrewLeapYear(hebrewYear) {
+		return HebrewAdarII
+	}
+	return HebrewAdar
+}
+
+// hebrewElapsedDays 元年提斯利月1日至该年提斯利月朔(molad)所在日的天数,已按朔在正午之后和周日、三、五推迟
+func hebrewElapsedDays(hebrewYear int) int {
+	// 一个朔望月为29日12小时793分(1小时为1080分)
+	monthsElapsed := floorDiv(235*hebrewYear-234, 19)
+	partsElapsed := 12084 + 13753*monthsElapsed
+	day := 29*monthsElapsed + floorDiv(partsElapsed, 25920)
+
+	// 岁首不能在周日、周三、周五
+	if floorMod(3*(day+1), 7) < 3 {
+		day++
+	}
+	return day
+}
+
+// hebrewYearLengthCorrection 使一年的天数为353-355或383-385天的推迟
+func hebrewYearLengthCorrection(hebrewYear int) int {
+	ny0 := hebrewElapsedDays(hebrewYear - 1)
+	ny1 := hebrewElapsedDays(hebrewYear)
+	ny2 := hebrewElapsedDays(hebrewYear + 1)
+
+	switch {
+	case ny2-ny1 == 356:
+		return 2
+	case ny1-ny0 == 382:
+		return 1
+	}
+	return 0
+}
+
+// hebrewNewYear 该年提斯利月1日的儒略日数
+func hebrewNewYear(hebrewYear int) int {
+	return cHebrewEpochJdn + hebrewElapsedDays(hebrewYear) + hebrewYearLengthCorrection(hebrewYear)
+}
+
+// hebrewYearDays 该年的天数
+func hebrewYearDays(hebrewYear int) int {
+	return hebrewNewYear(hebrewYear+1) - hebrewNewYear(hebrewYear)
+}
+
+// hebrewMonthDays 该年某月的天数
+func hebrewMonthDays(hebrewYear, hebrewMonth int) int {
+	switch hebrewMonth {
+	case HebrewIyyar, HebrewTammuz, HebrewElul, HebrewTevet, HebrewAdarII:
+		return 29
+	case HebrewAdar:
+		if !hebrewLeapYear(hebrewYear) {
+			return 29
+		}
+	case HebrewMarheshvan:
+		// 全年355或385天时玛西班月为30天
+		if hebrewYearDays(hebrewYear)%10 != 5 {
+			return 29
+		}
+	case HebrewKislev:
+		// 全年353或383天时基斯流月为29天
+		if hebrewYearDays(hebrewYear)%10 == 3 {
+			return 29
+		}
+	}
+	return 30
+}
+
+// hebrewJdn 希伯来历日期的儒略日数
+func hebrewJdn(hebrewYear, hebrewMonth, hebrewDay int) int {
+	jdn := hebrewNewYear(hebrewYear) + hebrewDay - 1
+
+	// 岁首为提斯利月,尼散月至以禄月在提斯利月之后
+	if hebrewMonth < HebrewTishrei {
+		for m := HebrewTishrei; m <= hebrewLastMonth(hebrewYear); m++ {
+			jdn += hebrewMonthDays(hebrewYear, m)
+		}
+		for m := HebrewNisan; m < hebrewMonth; m++ {
+			jdn += hebrewMonthDays(hebrewYear, m)
+		}
+	} else {
+		for m := HebrewTishrei; m < hebrewMonth; m++ {
+			jdn += hebrewMonthDays(hebrewYear, m)
+		}
+	}
+
+	return jdn
+}
+
+// hebrewFromJdn 儒略日数对应的希伯来历日期
+func hebrewFromJdn(jdn int) (hebrewYear, hebrewMonth, hebrewDay int) {
+	// 平均年长35975351/98496天
+	hebrewYear = floorDiv((jdn-cHebrewEpochJdn)*98496, 35975351)
+	for hebrewNewYear(hebrewYear+1) <= jdn {
+		hebrewYear++
+	}
+
+	start := HebrewNisan
+	if jdn < hebrewJdn(hebrewYear, HebrewNisan, 1) {
+		start = HebrewTishrei
+	}
+	hebrewMonth = start
+	for jdn > hebrewJdn(hebrewYear, hebrewMonth, hebrewMonthDays(hebrewYear, hebrewMonth)) {
+		hebrewMonth++
+	}
+
+	return hebrewYear, hebrewMonth, jdn - hebrewJdn(hebrewYear, hebrewMonth, 1) + 1
+}
+
+// floorMod 结果与b同号的取模
+func floorMod(a, b int) int {
+	return a - floorDiv(a, b)*b
+}
+
+// (*Calendar) GregorianToHebrew 公历转希伯来历,带月份名称和犹太节日
+func (c *Calendar) GregorianToHebrew(year, month, day int) HebrewDate {
+	hy, hm, hd := GregorianToHebrew(year, month, day)
+	l := c.locale()
+	fi := c.hebrewFestival(hy, hm, hd)
+
+	leap := hebrewLeapYear(hy)
+	name := l.HebrewMonths[hm-1]
+	if leap && hm == HebrewAdar {
+		name = l.HebrewMonths[13]
+	}
+
+	return HebrewDate{
+		Year:       hy,
+		Month:      hm,
+		Day:        hd,
+		MonthName:  name,
+		MonthDays:  hebrewMonthDays(hy, hm),
+		IsLeapYear: leap,
+		Festival:   &fi,
+		locale:     l,
+	}
+}
+
+// (*Calendar) HebrewToGregorian 希伯来历转公历,返回日历时区该日0时
+//
+// 错误同HebrewToGregorian
+func (c *Calendar) HebrewToGregorian(hebrewYear, hebrewMonth, hebrewDay int) (time.Time, error) {
+	return HebrewToGregorian(hebrewYear, hebrewMonth, hebrewDay, c.loc)
+}
+
+// (*Calendar) hebrewFestival 犹太节日
+func (c *Calendar) hebrewFestival(hebrewYear, hebrewMonth, hebrewDay int) FestivalItem {
+	fr := c.festivalRegistry()
+
+	// 节日表修改后清除已缓存的节日
+	c.tempData.jFD.sync(fr.version())
+
+	fds := c.tempData.jFD.getData(hebrewYear)
+
+	if len(fds) == 0 {
+		lastMonth := hebrewLastMonth(hebrewYear)
+		for k, v := range fr.Festivals(FestivalHebrew) {
+			re := gregorianFestivalDayRegexp.FindStringSubmatch(k)
+			if len(re) != 3 {
+				continue // 索引格式不正确
+			}
+			month, _ := strconv.Atoi(re[1])
+			day, _ := strconv.Atoi(re[2])
+			if month < 1 || month > lastMonth || day < 1 || day > 30 {
+				continue // 月份或日期不正确,平年没有13月
+			}
+
+			// 亚达月的节日在闰年移到第二亚达月
+			if month == HebrewAdar && lastMonth == HebrewAdarII {
+				month = HebrewAdarII
+			}
+
+			trueK := strconv.Itoa(month) + "M" + strconv.Itoa(day) + "D"
+			fds[trueK] = append(fds[trueK], strings.Split(v, ",")...)
+		}
+
+		c.tempData.jFD.setData(hebrewYear, fds)
+	}
+
+	festivalIndex := strconv.Itoa(hebrewMonth) + "M" + strconv.Itoa(hebrewDay) + "D"
+
+	// 该日的节日
+	return festivalItemFromNames(fds[festivalIndex])
+}
@@ -0,0 +1,108 @@
+package gocalendar
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGregorianToHebrew(t *testing.T) {
+	tests := []struct {
+		y, m, d    int
+		hy, hm, hd int
+	}{
+		{2021, 9, 7, 5782, HebrewTishrei, 1},
+		{2021, 9, 16, 5782, HebrewTishrei, 10},
+		{2023, 9, 16, 5784, HebrewTishrei, 1},
+		{2023, 12, 8, 5784, HebrewKislev, 25},
+		{2024, 3, 24, 5784, HebrewAdarII, 14},
+		{2024, 4, 23, 5784, HebrewNisan, 15},
+		{2000, 1, 1, 5760, HebrewTevet, 23},
+	}
+	for _, tt := range tests {
+		hy, hm, hd := GregorianToHebrew(tt.y, tt.m, tt.d)
+		g, err := HebrewToGregorian(tt.hy, tt.hm, tt.hd, time.UTC)
+		if hy == tt.hy && hm == tt.hm && hd == tt.hd && err == nil && g.Equal(time.Date(tt.y, time.Month(tt.m), tt.d, 0, 0, 0, 0, time.UTC)) {
+			t.Log("passed")
+		} else {
+			t.Error(tt, hy, hm, hd, g, err)
+		}
+	}
+
+	// 一年353-355或383-385天,逐日连续
+	for y := 5700; y < 5900; y++ {
+		days := hebrewYearDays(y)
+		if days != 353 && days != 354 && days != 355 && days != 383 && days != 384 && days != 385 {
+			t.Fatalf("%d: %d days", y, days)
+		}
+		if (days > 380) != hebrewLeapYear(y) {
+			t.Fatalf("%d: %d days, leap %v", y, days, hebrewLeapYear(y))
+		}
+	}
+	jdn := jdnOfDate(2020, 1, 1)
+	py, pm, pd := hebrewFromJdn(jdn)
+	for i := 1; i < 3*366; i++ {
+		hy, hm, hd := hebrewFromJdn(jdn + i)
+		if hebrewJdn(hy, hm, hd) != jdn+i || (hd != pd+1 && (hd != 1 || pd != hebrewMonthDays(py, pm))) {
+			t.Fatalf("%d: %d-%d-%d after %d-%d-%d", jdn+i, hy, hm, hd, py, pm, pd)
+		}
+		py, pm, pd = hy, hm, hd
+	}
+	t.Log("passed")
+
+	// 5782年是闰年,5783年没有第二亚达月
+	if _, err := HebrewToGregorian(5783, HebrewAdarII, 1, time.UTC); !errors.Is(err, ErrNoLeapMonth) {
+		t.Error(err)
+	}
+	if _, err := HebrewToGregorian(5782, HebrewIyyar, 30, time.UTC); !errors.Is(err, ErrDayOutOfMonth) {
+		t.Error(err)
+	}
+	if _, err := HebrewToGregorian(5782, 14, 1, time.UTC); !errors.Is(err, ErrInvalidDate) {
+		t.Error(err)
+	}
+}
+
+func TestCalendar_GregorianToHebrew(t *testing.T) {
+	c := NewCalendar(CalendarConfig{Grid: GridDay, TimeZoneName: "Asia/Jerusalem", Hebrew: true})
+
+	// 5784年是闰年,普珥节在第二亚达月
+	items := c.GenerateWithDate(2024, 3, 24)
+	hd := items[0].HebrewDate
+	if hd != nil && hd.String() == "5784年亚达二月14日" && hd.IsLeapYear && hd.Festival.Show[0] == "普珥节" {
+		t.Log("passed")
+	} else {
+		t.Error(hd)
+	}
+
+	hd2 := c.GregorianToHebrew(2024, 2, 23)
+	if hd2.Month == HebrewAdar && hd2.MonthName == "亚达一月" && len(hd2.Festival.Show) == 0 {
+		t.Log("passed")
+	} else {
+		t.Error(hd2, hd2.Festival)
+	}
+
+	c = NewCalendar(CalendarConfig{Locale: "en"})
+	if hd := c.GregorianToHebrew(2021, 9, 16); hd.String() == "10 Tishrei 5782" && hd.Festival.Show[0] == "赎罪日" {
+		t.Log("passed")
+	} else {
+		t.Error(hd)
+	}
+
+	g, err := c.HebrewToGregorian(5782, HebrewNisan, 15)
+	if err == nil && g.Format("2006-01-02") == "2022-04-16" {
+		t.Log("passed")
+	} else {
+		t.Error(g, err)
+	}
+}
+
+func TestRegisterLocale_HebrewDateFormat(t *testing.T) {
+	l := *LookupLocale("en")
+	l.Name = "en-HE"
+	l.HebrewDateFormat = ""
+	if err := RegisterLocale(&l); err != nil && LookupLocale("en-HE") == nil {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+}
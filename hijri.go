@@ -0,0 +1,267 @@
+package gocalendar
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 伊斯兰历(希吉来历)的算法
+const (
+	HijriTabular      = iota // 算术历法(30年11闰,与沙特Kuwaiti算法相同),与实际见月可能相差1-2天
+	HijriAstronomical        // 按朔日后日落时能否见新月推算,需设置Latitude、Longitude和LocationSet,未设置LocationSet时按算术历法
+)
+
+const (
+	// 伊斯兰历元年1月1日(公元622年7月16日,儒略历)的儒略日数
+	cHijriEpochJdn = 1948440
+
+	// 以2000年1月6日新月为0的朔望月序数k,加上该值为自伊斯兰历元年1月起的月序数,k=0为1420年10月
+	cHijriLunationOffset = 17037
+
+	// 见月条件:日落时月龄不少于15小时,且日月角距不少于10.5度
+	cCrescentMinAge        = 15.0 / 24
+	cCrescentMinElongation = 10.5
+)
+
+var (
+	// 伊斯兰历月份名称
+	hijriMonthNameArray = [12]string{"穆哈兰姆月", "色法尔月", "赖比尔·敖外鲁月", "赖比尔·阿色尼月", "主马达·敖外鲁月", "主马达·阿色尼月",
+		"赖哲卜月", "舍尔邦月", "赖买丹月", "闪瓦鲁月", "都尔喀尔德月", "都尔黑哲月"}
+
+	// 伊斯兰历节日,索引写法同公历节日的"月M日D",如10M1D表示10月1日,节日名称前加"*"号表示重要且在日历表上显示
+	hijriFestivalArray = map[string]string{"1M1D": "伊斯兰新年", "1M10D": "阿舒拉日", "3M12D": "*圣纪节", "7M27D": "登霄节",
+		"9M1D": "*斋月", "10M1D": "*开斋节", "12M10D": "*古尔邦节"}
+)
+
+// type HijriDate struct 伊斯兰历日期
+type HijriDate struct {
+	Year      int           `json:"year"`      // 年
+	Month     int           `json:"month"`     // 月
+	Day       int           `json:"day"`       // 日
+	MonthName string        `json:"monthName"` // 月份名称
+	MonthDays int           `json:"monthDays"` // 该月天数,29或30
+	Festival  *FestivalItem `json:"festival"`  // 伊斯兰历节日
+
+	locale *Locale // 显示用的语言
+}
+
+// (HijriDate) String 伊斯兰历日期显示
+func (hd HijriDate) String() string {
+	return fmt.Sprintf(hd.locale.orDefault().HijriDateFormat, hd.Year, hd.MonthName, hd.Day)
+}
+
+// GregorianToHijriTabular 公历转伊斯兰历,算术历法
+//
+// 与农历相同,1582年10月15日之前的日期按儒略历
+func GregorianToHijriTabular(year, month, day int) (hijriYear, hijriMonth, hijriDay int) {
+	return hijriTabularFromJdn(jdnOfDate(year, month, day))
+}
+
+// HijriTabularToGregorian 伊斯兰历转公历,算术历法
+//
+// 月份或日期不合法时返回ErrInvalidDate,日期大于该月天数时返回ErrDayOutOfMonth
+func HijriTabularToGregorian(hijriYear, hijriMonth, hijriDay int) (year, month, day int, err error) {
+	if hijriYear < 1 || hijriMonth < 1 || hijriMonth > 12 || hijriDay < 1 || hijriDay > 30 {
+		return 0, 0, 0, ErrInvalidDate
+	}
+	if hijriDay > hijriTabularMonthDays(hijriYear, hijriMonth) {
+		return 0, 0, 0, ErrDayOutOfMonth
+	}
+
+	tm := JdToTimeMap(float64(hijriTabularJdn(hijriYear, hijriMonth, hijriDay)))
+	return tm["year"], tm["month"], tm["day"], nil
+}
+
+// hijriTabularJdn 算术历法伊斯兰历日期的儒略日数
+func hijriTabularJdn(hijriYear, hijriMonth, hijriDay int) int {
+	// 单月30天,双月29天,30年中第2、5、7、10、13、16、18、21、24、26、29年的12月为30天
+	return hijriDay + (59*(hijriMonth-1)+1)/2 + (hijriYear-1)*354 + floorDiv(3+11*hijriYear, 30) + cHijriEpochJdn - 1
+}
+
+// hijriTabularFromJdn 儒略日数对应的算术历法伊斯兰历日期
+func hijriTabularFromJdn(jdn int) (hijriYear, hijriMonth, hijriDay int) {
+	hijriYear = floorDiv(30*(jdn-cHijriEpochJdn)+10646, 10631)
+
+	hijriMonth = 12
+	for m := 2; m <= 12; m++ {
+		if jdn < hijriTabularJdn(hijriYear, m, 1) {
+			hijriMonth = m - 1
+			break
+		}
+	}
+
+	return hijriYear, hijriMonth, jdn - hijriTabularJdn(hijriYear, hijriMonth, 1) + 1
+}
+
+// hijriTabularMonthDays 算术历法伊斯兰历某月的天数
+func hijriTabularMonthDays(hijriYear, hijriMonth int) int {
+	if hijriMonth == 12 {
+		return hijriTabularJdn(hijriYear+1, 1, 1) - hijriTabularJdn(hijriYear, 12, 1)
+	}
+	return 30 - (hijriMonth+1)%2
+}
+
+// floorDiv 向下取整的整数除法
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+// (*Calendar) GregorianToHijri 公历转伊斯兰历,算法由CalendarConfig.HijriMethod指定
+func (c *Calendar) GregorianToHijri(year, month, day int) HijriDate {
+	hy, hm, hd, days := c.hijriFromJdn(jdnOfDate(year, month, day))
+	l := c.locale()
+	fi := c.hijriFestival(hy, hm, hd)
+
+	return HijriDate{
+		Year:      hy,
+		Month:     hm,
+		Day:       hd,
+		MonthName: l.HijriMonths[hm-1],
+		MonthDays: days,
+		Festival:  &fi,
+		locale:    l,
+	}
+}
+
+// (*Calendar) HijriToGregorian 伊斯兰历转公历,算法由CalendarConfig.HijriMethod指定
+//
+// 月份或日期不合法时返回ErrInvalidDate,日期大于该月天数时返回ErrDayOutOfMonth,超出计算范围时返回ErrOutOfRange
+func (c *Calendar) HijriToGregorian(hijriYear, hijriMonth, hijriDay int) (time.Time, error) {
+	days, err := c.HijriMonthDays(hijriYear, hijriMonth)
+	if err == nil && (hijriDay < 1 || hijriDay > 30) {
+		err = ErrInvalidDate
+	}
+	if err == nil && hijriDay > days {
+		err = ErrDayOutOfMonth
+	}
+	if err != nil {
+		return time.Time{}, &DateError{Op: "HijriToGregorian", Year: hijriYear, Month: hijriMonth, Day: hijriDay, Err: err}
+	}
+
+	n := (hijriYear-1)*12 + hijriMonth - 1
+	return lunarTableTime(c.hijriMonthStart(n)+hijriDay-1, c.loc), nil
+}
+
+// (*Calendar) HijriMonthDays 伊斯兰历某月的天数,算法由CalendarConfig.HijriMethod指定
+//
+// 月份不合法时返回ErrInvalidDate,超出计算范围时返回ErrOutOfRange
+func (c *Calendar) HijriMonthDays(hijriYear, hijriMonth int) (int, error) {
+	if hijriYear < 1 || hijriMonth < 1 || hijriMonth > 12 {
+		return 0, ErrInvalidDate
+	}
+
+	// 伊斯兰历年比公历年短约11天
+	if err := checkYear(hijriYear*354/365 + 622); err != nil {
+		return 0, err
+	}
+
+	n := (hijriYear-1)*12 + hijriMonth - 1
+	return c.hijriMonthStart(n+1) - c.hijriMonthStart(n), nil
+}
+
+// (*Calendar) hijriFromJdn 儒略日数对应的伊斯兰历日期和该月天数
+func (c *Calendar) hijriFromJdn(jdn int) (hijriYear, hijriMonth, hijriDay, monthDays int) {
+	// 算术历法与按见月推算的月首最多相差两三天,以此为起点前后调整
+	ty, tm, _ := hijriTabularFromJdn(jdn)
+	n := (ty-1)*12 + tm - 1
+
+	start := c.hijriMonthStart(n)
+	for jdn < start {
+		n--
+		start = c.hijriMonthStart(n)
+	}
+	next := c.hijriMonthStart(n + 1)
+	for jdn >= next {
+		n++
+		start, next = next, c.hijriMonthStart(n+1)
+	}
+
+	return floorDiv(n, 12) + 1, n - floorDiv(n, 12)*12 + 1, jdn - start + 1, next - start
+}
+
+// (*Calendar) hijriMonthStart 自伊斯兰历元年1月起第n个月(0为元年1月)1日的儒略日数
+//
+// 未设置地理位置(LocationSet)时不按0度经纬度见月,改按算术历法
+func (c *Calendar) hijriMonthStart(n int) int {
+	if c.config.HijriMethod != HijriAstronomical || !c.config.LocationSet {
+		return hijriTabularJdn(floorDiv(n, 12)+1, n-floorDiv(n, 12)*12+1, 1)
+	}
+
+	k := cacheKey{kind: cacheHijriMonthStart, year: n, variant: fmt.Sprintf("%s|%g|%g|%g", c.loc, c.config.Latitude, c.config.Longitude, c.config.Elevation)}
+	ac := c.astroCache()
+	if v, ok := ac.get(k); ok {
+		return v.(int)
+	}
+
+	jdn := c.crescentMonthStart(float64(n - cHijriLunationOffset))
+	ac.set(k, jdn)
+
+	return jdn
+}
+
+// (*Calendar) crescentMonthStart 朔望月序数k的朔之后,首次能见新月的次日的儒略日数
+//
+// 从朔日当天起逐日检查日落时的月龄和日月角距,日落时刻按配置的地理位置计算,极昼极夜时以当地18时代替
+func (c *Calendar) crescentMonthStart(k float64) int {
+	conj := moonPhaseUtJd(k)
+	d := JdToTime(conj, c.loc)
+	year, month, day := d.Date()
+	jdn := jdnOfDate(year, int(month), day)
+
+	for i := 0; i < 3; i++ {
+		noon := timeToJd(time.Date(year, month, day+i, 12, 0, 0, 0, c.loc))
+		sunset, polar := sunAltitudeJd(noon, c.config.Latitude, c.config.Longitude, cSunriseAltitude, false)
+		if polar != 0 {
+			sunset = noon + 0.25
+		}
+
+		if age := sunset - conj; age >= cCrescentMinAge {
+			if _, angle := moonIlluminationJd(sunset); 180-angle >= cCrescentMinElongation {
+				return jdn + i + 1
+			}
+		}
+	}
+
+	// 朔后第三日日落时月龄已超过两天,总能见月
+	return jdn + 4
+}
+
+// (*Calendar) hijriFestival 伊斯兰历节日
+func (c *Calendar) hijriFestival(hijriYear, hijriMonth, hijriDay int) FestivalItem {
+	fr := c.festivalRegistry()
+
+	// 节日表修改后清除已缓存的节日
+	c.tempData.hFD.sync(fr.version())
+
+	fds := c.tempData.hFD.getData(hijriYear)
+
+	if len(fds) == 0 {
+		for k, v := range fr.Festivals(FestivalHijri) {
+			re := gregorianFestivalDayRegexp.FindStringSubmatch(k)
+			if len(re) != 3 {
+				continue // 索引格式不正确
+			}
+			month, _ := strconv.Atoi(re[1])
+			day, _ := strconv.Atoi(re[2])
+			if month < 1 || month > 12 || day < 1 || day > 30 {
+				continue // 月份或日期不正确
+			}
+
+			trueK := strconv.Itoa(month) + "M" + strconv.Itoa(day) + "D"
+			fds[trueK] = append(fds[trueK], strings.Split(v, ",")...)
+		}
+
+		c.tempData.hFD.setData(hijriYear, fds)
+	}
+
+	festivalIndex := strconv.Itoa(hijriMonth) + "M" + strconv.Itoa(hijriDay) + "D"
+
+	// 该日的节日
+	return festivalItemFromNames(fds[festivalIndex])
+}
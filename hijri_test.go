@@ -0,0 +1,135 @@
+package gocalendar
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGregorianToHijriTabular(t *testing.T) {
+	tests := []struct {
+		y, m, d    int
+		hy, hm, hd int
+	}{
+		{622, 7, 16, 1, 1, 1},
+		{2000, 1, 8, 1420, 10, 1},
+		{2021, 4, 13, 1442, 9, 1},
+		{2021, 5, 13, 1442, 10, 1},
+		{2023, 3, 23, 1444, 9, 1},
+	}
+	for _, tt := range tests {
+		hy, hm, hd := GregorianToHijriTabular(tt.y, tt.m, tt.d)
+		y, m, d, err := HijriTabularToGregorian(tt.hy, tt.hm, tt.hd)
+		if hy == tt.hy && hm == tt.hm && hd == tt.hd && err == nil && y == tt.y && m == tt.m && d == tt.d {
+			t.Log("passed")
+		} else {
+			t.Error(tt, hy, hm, hd, y, m, d, err)
+		}
+	}
+
+	// 1442年为闰年,12月30天;1443年12月29天
+	if _, _, _, err := HijriTabularToGregorian(1442, 12, 30); err != nil {
+		t.Error(err)
+	}
+	if _, _, _, err := HijriTabularToGregorian(1443, 12, 30); !errors.Is(err, ErrDayOutOfMonth) {
+		t.Error(err)
+	}
+	if _, _, _, err := HijriTabularToGregorian(1443, 13, 1); !errors.Is(err, ErrInvalidDate) {
+		t.Error(err)
+	}
+}
+
+func TestCalendar_GregorianToHijri(t *testing.T) {
+	tabular := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai"})
+	astro := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai", Latitude: 38.47, Longitude: 106.27, LocationSet: true, HijriMethod: HijriAstronomical})
+
+	hd := tabular.GregorianToHijri(2021, 5, 13)
+	if hd.String() == "1442年闪瓦鲁月1日" && hd.MonthDays == 29 && len(hd.Festival.Show) == 1 && hd.Festival.Show[0] == "开斋节" {
+		t.Log("passed")
+	} else {
+		t.Error(hd, hd.Festival)
+	}
+
+	// 2021年4月12日朔,银川当天日落时月龄不足15小时,13日傍晚见月,14日为赖买丹月1日
+	hd = astro.GregorianToHijri(2021, 4, 14)
+	if hd.Year == 1442 && hd.Month == 9 && hd.Day == 1 {
+		t.Log("passed")
+	} else {
+		t.Error(hd)
+	}
+
+	// 按见月推算的每月29或30天,逐日连续,与伊斯兰历转公历一致
+	for _, c := range []*Calendar{tabular, astro} {
+		prev := c.GregorianToHijri(2020, 12, 31)
+		for d := time.Date(2021, 1, 1, 0, 0, 0, 0, c.loc); d.Year() < 2023; d = d.AddDate(0, 0, 1) {
+			hd := c.GregorianToHijri(d.Year(), int(d.Month()), d.Day())
+			next := prev.Day+1 == hd.Day && prev.Month == hd.Month
+			newMonth := hd.Day == 1 && prev.Day == prev.MonthDays && (hd.Month == prev.Month%12+1)
+			if (!next && !newMonth) || hd.MonthDays < 29 || hd.MonthDays > 30 {
+				t.Fatalf("%s: %v after %v", d.Format("2006-01-02"), hd, prev)
+			}
+
+			g, err := c.HijriToGregorian(hd.Year, hd.Month, hd.Day)
+			if err != nil || !g.Equal(d) {
+				t.Fatalf("%v -> %s, want %s (%v)", hd, g, d, err)
+			}
+			prev = hd
+		}
+	}
+	t.Log("passed")
+
+	if _, err := astro.HijriToGregorian(1442, 13, 1); !errors.Is(err, ErrInvalidDate) {
+		t.Error(err)
+	}
+	if _, err := astro.HijriToGregorian(3000, 1, 1); !errors.Is(err, ErrOutOfRange) {
+		t.Error(err)
+	}
+
+	// 未设置LocationSet时按算术历法,不按0度经纬度见月
+	noLoc := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai", HijriMethod: HijriAstronomical})
+	for d := time.Date(2021, 1, 1, 0, 0, 0, 0, noLoc.loc); d.Year() < 2022; d = d.AddDate(0, 0, 1) {
+		if hd, want := noLoc.GregorianToHijri(d.Year(), int(d.Month()), d.Day()), tabular.GregorianToHijri(d.Year(), int(d.Month()), d.Day()); hd.String() != want.String() || hd.MonthDays != want.MonthDays {
+			t.Fatalf("%s: %v, want %v", d.Format("2006-01-02"), hd, want)
+		}
+	}
+	t.Log("passed")
+}
+
+func TestCalendar_HijriItem(t *testing.T) {
+	c := NewCalendar(CalendarConfig{Grid: GridDay, TimeZoneName: "Asia/Shanghai", Locale: "en", Hijri: true})
+
+	items := c.GenerateWithDate(2021, 7, 20)
+	hd := items[0].HijriDate
+	if hd != nil && hd.String() == "10 Dhu al-Hijjah 1442 AH" && hd.Festival.Show[0] == "古尔邦节" {
+		t.Log("passed")
+	} else {
+		t.Error(hd)
+	}
+
+	// 自定义节日
+	fr := NewFestivalRegistry(DefaultFestivalRegistry())
+	if err := fr.Add(FestivalHijri, "12M9D", "*阿拉法特日"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fr.Add(FestivalHijri, "12M$", "x"); err == nil {
+		t.Error("12M$")
+	}
+	c.SetFestivalRegistry(fr)
+	items = c.GenerateWithDate(2021, 7, 19)
+	if hd := items[0].HijriDate; hd.Day == 9 && hd.Festival.Show[0] == "阿拉法特日" {
+		t.Log("passed")
+	} else {
+		t.Error(hd)
+	}
+}
+
+func TestRegisterLocale_HijriDateFormat(t *testing.T) {
+	l := *LookupLocale("en")
+	l.Name = "en-HI"
+	l.HijriDateFormat = ""
+	if err := RegisterLocale(&l); err != nil && LookupLocale("en-HI") == nil {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,78 @@
+package gocalendar
+
+import "math"
+
+// 历史农历的历法规则,按农历年所在的年代选用
+const (
+	lunarRulePingQiPingShuo = iota // 平气平朔,唐以前
+	lunarRulePingQiDingShuo        // 平气定朔,唐戊寅元历至明大统历
+	lunarRuleDingQiDingShuo        // 定气定朔,清时宪历起
+)
+
+const (
+	// 唐戊寅元历起用定朔的年份
+	cDingShuoStartYear = 619
+
+	// 清时宪历起用定气的年份
+	cDingQiStartYear = 1645
+)
+
+// 历史农历中已知与推算不同的朔日,索引为推算的朔日,值为当时历书所用的朔日,均为东八区日期的儒略日数(JDN)
+//
+// 当时的历法本身有误差,个别月份的朔日与按平气、定朔等规则推算的不同。
+// 只收录有史料可互相印证的,注明依据;其它可根据《中国史历日和中西历日对照表》增补。
+// 目前只有一条,其它月份都没有校对,历史农历的结果只是按规则推算
+var historicalNewMoonCorrections = map[int]int{
+	// 天启七年八月甲午朔,推算为乙未。
+	// 熹宗崩于八月乙卯(二十二日,1627年9月30日),思宗即位于八月丁巳(二十四日,10月2日)
+	jdnOfDate(1627, 9, 10): jdnOfDate(1627, 9, 9),
+}
+
+// (*Calendar) lunarRule 该农历年所用的历法规则
+//
+// 未设置CalendarConfig.HistoricalLunar时总是定气定朔
+func (c *Calendar) lunarRule(year int) int {
+	switch {
+	case !c.config.HistoricalLunar || year >= cDingQiStartYear:
+		return lunarRuleDingQiDingShuo
+	case year >= cDingShuoStartYear:
+		return lunarRulePingQiDingShuo
+	}
+	return lunarRulePingQiPingShuo
+}
+
+// meanQiSinceWinterSolstice 平气,自上一年冬至起将冬至至冬至的一年等分,返回连续16个中气(UT儒略日)
+//
+// Delta T超出有效范围时返回其错误
+func meanQiSinceWinterSolstice(year int) ([16]float64, error) {
+	// meanSolarTermsJd以春分为0,冬至为18
+	dt0, err := deltaTDays(float64(year-1), 12)
+	dt1, err1 := deltaTDays(float64(year), 12)
+	if err == nil {
+		err = err1
+	}
+	dz0 := meanSolarTermsJd(float64(year - 1))[18] - dt0
+	dz1 := meanSolarTermsJd(float64(year))[18] - dt1
+
+	var qss [16]float64
+	for i := range qss {
+		qss[i] = Round(dz0+(dz1-dz0)*float64(i)/12, 10)
+	}
+
+	return qss, err
+}
+
+// meanNewMoonJd 平朔,自2000年1月起第k个朔望月的平均新月点(TT儒略日)
+func meanNewMoonJd(k float64) float64 {
+	_, jd := meanNewMoon(newMoonEstimated(k) + cMSM/2)
+	return jd
+}
+
+// correctHistoricalNewMoon 按historicalNewMoonCorrections修正朔日,只修正到整日
+func correctHistoricalNewMoon(jd float64) float64 {
+	jdn := int(math.Floor(jd + 0.5 + cChineseTimeOffsetDays))
+	if v, ok := historicalNewMoonCorrections[jdn]; ok {
+		return jd + float64(v-jdn)
+	}
+	return jd
+}
@@ -0,0 +1,120 @@
+package gocalendar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalendar_HistoricalLunar(t *testing.T) {
+	d := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai"})
+	h := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai", HistoricalLunar: true})
+
+	// 明大统历用平气,闰月与按定气推算的不同
+	tests := []struct {
+		year        int
+		leap, astro int
+	}{
+		{1612, 11, 0}, // 万历四十年闰十一月
+		{1623, 10, 8}, // 天启三年闰十月
+		{1631, 11, 0}, // 崇祯四年闰十一月
+		{1640, 1, 3},  // 崇祯十三年闰正月
+	}
+	for _, tt := range tests {
+		if h.LunarLeap(tt.year) == tt.leap && d.LunarLeap(tt.year) == tt.astro {
+			t.Log("passed")
+		} else {
+			t.Error(tt, h.LunarLeap(tt.year), d.LunarLeap(tt.year))
+		}
+	}
+
+	// 1631年闰十一月,1632年正月初一推迟到2月20日
+	if g, err := h.LunarToGregorian(1632, 1, 1, false); err == nil && g.Format("2006-01-02") == "1632-02-20" {
+		t.Log("passed")
+	} else {
+		t.Error(g, err)
+	}
+
+	// 时宪历(1645年)起与定气定朔相同
+	for y := 1645; y < 1660; y++ {
+		ga, _ := d.LunarToGregorian(y, 1, 1, false)
+		gb, _ := h.LunarToGregorian(y, 1, 1, false)
+		if d.LunarLeap(y) != h.LunarLeap(y) || !ga.Equal(gb) {
+			t.Fatal(y, d.LunarLeap(y), h.LunarLeap(y), ga, gb)
+		}
+	}
+	t.Log("passed")
+
+	// 唐以前平朔,大小月相间,不会连续两个小月
+	prev := 0
+	for y := 500; y < 600; y++ {
+		leap := h.LunarLeap(y)
+		for m := 1; m <= 12; m++ {
+			for _, isLeap := range []bool{false, true} {
+				if isLeap && m != leap {
+					continue
+				}
+				days, err := h.LunarMonthDays(y, m, isLeap)
+				if err != nil || days < 29 || days > 30 || (days == 29 && prev == 29) {
+					t.Fatal(y, m, isLeap, days, prev, err)
+				}
+				prev = days
+			}
+		}
+	}
+	t.Log("passed")
+
+	// 平气等分冬至至冬至的一年
+	qss, _ := meanQiSinceWinterSolstice(1600)
+	for i := 2; i < len(qss); i++ {
+		if d := (qss[i] - qss[i-1]) - (qss[1] - qss[0]); d > 1e-6 || d < -1e-6 {
+			t.Fatal(i, qss)
+		}
+	}
+	t.Log("passed")
+}
+
+func TestCalendar_HistoricalNewMoonCorrections(t *testing.T) {
+	d := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai"})
+	h := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai", HistoricalLunar: true})
+
+	// 天启七年八月甲午朔,熹宗崩于八月乙卯(二十二日),思宗即位于八月丁巳(二十四日)
+	tests := []struct {
+		y, m, d int
+		day     int
+	}{
+		{1627, 9, 9, 1},
+		{1627, 9, 30, 22},
+		{1627, 10, 2, 24},
+	}
+	for _, tt := range tests {
+		ld := h.GregorianToLunar(tt.y, tt.m, tt.d)
+		if ld.Year == 1627 && ld.Month == 8 && ld.Day == tt.day {
+			t.Log("passed")
+		} else {
+			t.Error(tt, ld)
+		}
+	}
+	g, err := h.LunarToGregorian(1627, 8, 1, false)
+	if gz := h.ChineseSexagenaryCycle(g.Add(12 * time.Hour)); err == nil && g.Format("2006-01-02") == "1627-09-09" && gz.Day.HSN+gz.Day.EBN == "甲午" {
+		t.Log("passed")
+	} else {
+		t.Error(g, err)
+	}
+
+	// 七月因此为小月,九月朔不变
+	days7, _ := h.LunarMonthDays(1627, 7, false)
+	days8, _ := h.LunarMonthDays(1627, 8, false)
+	g9, _ := h.LunarToGregorian(1627, 9, 1, false)
+	if days7 == 29 && days8 == 30 && g9.Format("2006-01-02") == "1627-10-09" {
+		t.Log("passed")
+	} else {
+		t.Error(days7, days8, g9)
+	}
+
+	// 未设置HistoricalLunar时按推算,不修正
+	if ld := d.GregorianToLunar(1627, 9, 30); ld.Month == 8 && ld.Day == 21 {
+		t.Log("passed")
+	} else {
+		t.Error(ld)
+	}
+}
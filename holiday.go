@@ -0,0 +1,285 @@
+package gocalendar
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DayType 日期类型(工作日、周末、法定节假日、调休上班日)
+type DayType int
+
+const (
+	DayTypeWorkday       DayType = iota + 1 // 工作日
+	DayTypeWeekend                          // 周末
+	DayTypeHoliday                          // 法定节假日(含调休放假的日期)
+	DayTypeMakeupWorkday                    // 调休上班日
+)
+
+// 日期类型名称,索引为DayType,为默认语言的DayTypes
+var dayTypeNameArray = [5]string{"", "工作日", "周末", "节假日", "调休上班"}
+
+// 节假日安排中日期的格式
+const holidayDateLayout = "2006-01-02"
+
+// type holidayRange struct 节假日安排文件中的一个节日安排
+type holidayRange struct {
+	Name     string   `json:"name"`     // 节日名称
+	Start    string   `json:"start"`    // 放假开始日期 2006-01-02
+	End      string   `json:"end"`      // 放假结束日期(包括该日) 2006-01-02
+	Workdays []string `json:"workdays"` // 调休上班的日期
+}
+
+// type holidayScheduleFile struct 节假日安排文件
+type holidayScheduleFile struct {
+	Version string                    `json:"version"` // 数据版本
+	Years   map[string][]holidayRange `json:"years"`   // 以年份为索引的节假日安排
+}
+
+// type HolidaySchedule struct 中国大陆法定节假日及调休安排
+//
+// 数据为JSON格式,如:
+//
+//	{"version":"2025.1","years":{"2025":[
+//	    {"name":"春节","start":"2025-01-28","end":"2025-02-04","workdays":["2025-01-26","2025-02-08"]}
+//	]}}
+//
+// 安排中没有的年份按周一至周五为工作日,周六周日为周末计算
+type HolidaySchedule struct {
+	version  string
+	holidays map[string]string // 放假日期对应的节日名称
+	workdays map[string]string // 调休上班日期对应的节日名称
+	years    map[int]bool      // 有节假日安排的年份
+}
+
+var (
+	// 默认节假日安排
+	defaultHolidaySchedule = mustParseHolidaySchedule(holidayScheduleData)
+
+	// 保护defaultHolidaySchedule
+	defaultHolidayScheduleMu sync.RWMutex
+)
+
+// ParseHolidaySchedule 解析节假日安排数据
+func ParseHolidaySchedule(data []byte) (*HolidaySchedule, error) {
+	var f holidayScheduleFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+
+	hs := &HolidaySchedule{
+		version:  f.Version,
+		holidays: make(map[string]string),
+		workdays: make(map[string]string),
+		years:    make(map[int]bool),
+	}
+
+	for ys, hrs := range f.Years {
+		year, err := strconv.Atoi(ys)
+		if err != nil {
+			return nil, errors.New("节假日安排年份错误:" + ys)
+		}
+		hs.years[year] = true
+
+		for _, hr := range hrs {
+			start, err := time.Parse(holidayDateLayout, hr.Start)
+			if err != nil {
+				return nil, err
+			}
+			end, err := time.Parse(holidayDateLayout, hr.End)
+			if err != nil {
+				return nil, err
+			}
+			if end.Before(start) {
+				return nil, errors.New("节假日安排放假结束日期早于开始日期:" + hr.Name)
+			}
+
+			for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+				hs.holidays[d.Format(holidayDateLayout)] = hr.Name
+			}
+
+			for _, w := range hr.Workdays {
+				wd, err := time.Parse(holidayDateLayout, w)
+				if err != nil {
+					return nil, err
+				}
+				hs.workdays[wd.Format(holidayDateLayout)] = hr.Name
+			}
+		}
+	}
+
+	return hs, nil
+}
+
+// LoadHolidaySchedule 从r中读取并解析节假日安排数据
+//
+// 可以用该方法在运行时载入新发布的节假日安排,再用 SetDefaultHolidaySchedule 或 CalendarConfig.HolidaySchedule 使用它
+func LoadHolidaySchedule(r io.Reader) (*HolidaySchedule, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseHolidaySchedule(data)
+}
+
+// mustParseHolidaySchedule 解析内置的节假日安排数据,出错则panic
+func mustParseHolidaySchedule(data []byte) *HolidaySchedule {
+	hs, err := ParseHolidaySchedule(data)
+	if err != nil {
+		panic("gocalendar: 内置节假日安排数据错误: " + err.Error())
+	}
+	return hs
+}
+
+// DefaultHolidaySchedule 默认节假日安排
+//
+// 未设置CalendarConfig.HolidaySchedule的Calendar都使用该节假日安排
+func DefaultHolidaySchedule() *HolidaySchedule {
+	defaultHolidayScheduleMu.RLock()
+	defer defaultHolidayScheduleMu.RUnlock()
+	return defaultHolidaySchedule
+}
+
+// SetDefaultHolidaySchedule 替换默认节假日安排
+//
+// hs为nil时恢复为内置的节假日安排
+func SetDefaultHolidaySchedule(hs *HolidaySchedule) {
+	if hs == nil {
+		hs = mustParseHolidaySchedule(holidayScheduleData)
+	}
+
+	defaultHolidayScheduleMu.Lock()
+	defer defaultHolidayScheduleMu.Unlock()
+	defaultHolidaySchedule = hs
+}
+
+// (*HolidaySchedule) Version 节假日安排数据版本
+func (hs *HolidaySchedule) Version() string {
+	return hs.version
+}
+
+// (*HolidaySchedule) Years 有节假日安排的年份,从小到大排列
+func (hs *HolidaySchedule) Years() []int {
+	var years []int
+	for y := range hs.years {
+		years = append(years, y)
+	}
+	sort.Ints(years)
+	return years
+}
+
+// (*HolidaySchedule) Covers 是否有该年的节假日安排
+func (hs *HolidaySchedule) Covers(year int) bool {
+	return hs.years[year]
+}
+
+// (*HolidaySchedule) DayType 日期类型
+//
+// 以t所在时区的日期计算
+func (hs *HolidaySchedule) DayType(t time.Time) DayType {
+	k := t.Format(holidayDateLayout)
+	if _, ok := hs.holidays[k]; ok {
+		return DayTypeHoliday
+	}
+	if _, ok := hs.workdays[k]; ok {
+		return DayTypeMakeupWorkday
+	}
+
+	if w := t.Weekday(); w == time.Saturday || w == time.Sunday {
+		return DayTypeWeekend
+	}
+
+	return DayTypeWorkday
+}
+
+// (*HolidaySchedule) HolidayName 放假或调休上班对应的节日名称
+//
+// 非节假日也非调休上班日返回空字符串
+func (hs *HolidaySchedule) HolidayName(t time.Time) string {
+	k := t.Format(holidayDateLayout)
+	if name, ok := hs.holidays[k]; ok {
+		return name
+	}
+	return hs.workdays[k]
+}
+
+// (DayType) IsWorkday 是否要上班(工作日或调休上班日)
+func (dt DayType) IsWorkday() bool {
+	return dt == DayTypeWorkday || dt == DayTypeMakeupWorkday
+}
+
+// (DayType) String 日期类型在默认语言中的名称
+//
+// DayType本身不带语言,日历单元按其语言显示的名称见CalendarItem.DayTypeName,其它语言用(*Locale) DayType
+func (dt DayType) String() string {
+	return defaultLocale().DayType(dt)
+}
+
+// (*Calendar) holidaySchedule 当前使用的节假日安排
+func (c *Calendar) holidaySchedule() *HolidaySchedule {
+	if c.config.HolidaySchedule != nil {
+		return c.config.HolidaySchedule
+	}
+	return DefaultHolidaySchedule()
+}
+
+// (*Calendar) DayType 日期类型
+//
+// t按日历的时区取日期
+func (c *Calendar) DayType(t time.Time) DayType {
+	return c.holidaySchedule().DayType(t.In(c.loc))
+}
+
+// (*Calendar) IsWorkday 是否是工作日(包括调休上班日)
+func (c *Calendar) IsWorkday(t time.Time) bool {
+	return c.DayType(t).IsWorkday()
+}
+
+// (*Calendar) IsHoliday 是否是法定节假日(包括调休放假的日期,不包括普通周末)
+func (c *Calendar) IsHoliday(t time.Time) bool {
+	return c.DayType(t) == DayTypeHoliday
+}
+
+// (*Calendar) NextWorkday t之后的第一个工作日
+//
+// 返回的时间时分秒与t相同
+func (c *Calendar) NextWorkday(t time.Time) time.Time {
+	t = t.In(c.loc)
+	hs := c.holidaySchedule()
+	for {
+		t = t.AddDate(0, 0, 1)
+		if hs.DayType(t).IsWorkday() {
+			return t
+		}
+	}
+}
+
+// (*Calendar) WorkdaysBetween start至end之间(包括start和end两日)的工作日天数
+//
+// start晚于end时交换两者
+func (c *Calendar) WorkdaysBetween(start, end time.Time) int {
+	start, end = start.In(c.loc), end.In(c.loc)
+	if start.After(end) {
+		start, end = end, start
+	}
+
+	hs := c.holidaySchedule()
+	sy, sm, sd := start.Date()
+	ey, em, ed := end.Date()
+	last := time.Date(ey, em, ed, 0, 0, 0, 0, c.loc)
+
+	n := 0
+	for d := time.Date(sy, sm, sd, 0, 0, 0, 0, c.loc); !d.After(last); d = d.AddDate(0, 0, 1) {
+		if hs.DayType(d).IsWorkday() {
+			n++
+		}
+	}
+
+	return n
+}
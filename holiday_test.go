@@ -0,0 +1,129 @@
+package gocalendar
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHolidaySchedule_DayType(t *testing.T) {
+	hs := DefaultHolidaySchedule()
+
+	cases := []struct {
+		date string
+		dt   DayType
+	}{
+		{"2024-02-10", DayTypeHoliday},       // 春节
+		{"2024-02-18", DayTypeMakeupWorkday}, // 周日调休上班
+		{"2024-02-19", DayTypeWorkday},
+		{"2024-02-24", DayTypeWeekend},
+		{"2022-12-31", DayTypeHoliday},       // 2023年元旦假期
+		{"2026-02-23", DayTypeHoliday},       // 2026年春节放假9天
+		{"2026-02-28", DayTypeMakeupWorkday}, // 周六调休上班
+		{"2026-09-20", DayTypeMakeupWorkday}, // 国庆节周日调休上班
+		{"2030-01-05", DayTypeWeekend},       // 没有节假日安排的年份
+	}
+
+	for _, v := range cases {
+		d, _ := time.Parse(holidayDateLayout, v.date)
+		if dt := hs.DayType(d); dt != v.dt {
+			t.Error(v.date, dt)
+		}
+	}
+
+	if hs.HolidayName(time.Date(2024, 2, 18, 0, 0, 0, 0, time.UTC)) == "春节" {
+		t.Log("passed")
+	} else {
+		t.Error(hs.HolidayName(time.Date(2024, 2, 18, 0, 0, 0, 0, time.UTC)))
+	}
+}
+
+func TestLoadHolidaySchedule(t *testing.T) {
+	data := `{"version":"2026.1","years":{"2026":[{"name":"元旦","start":"2026-01-01","end":"2026-01-03","workdays":["2026-01-04"]}]}}`
+	hs, err := LoadHolidaySchedule(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hs.Version() == "2026.1" && hs.Covers(2026) && !hs.Covers(2025) {
+		t.Log("passed")
+	} else {
+		t.Error(hs.Version(), hs.Years())
+	}
+
+	c := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai", HolidaySchedule: hs})
+	if c.IsHoliday(time.Date(2026, 1, 2, 0, 0, 0, 0, c.loc)) && c.IsWorkday(time.Date(2026, 1, 4, 0, 0, 0, 0, c.loc)) {
+		t.Log("passed")
+	} else {
+		t.Error(c.DayType(time.Date(2026, 1, 2, 0, 0, 0, 0, c.loc)), c.DayType(time.Date(2026, 1, 4, 0, 0, 0, 0, c.loc)))
+	}
+
+	if _, err := ParseHolidaySchedule([]byte(`{"years":{"2026":[{"name":"x","start":"2026-01-03","end":"2026-01-01"}]}}`)); err == nil {
+		t.Error("结束日期早于开始日期")
+	}
+}
+
+func TestCalendar_NextWorkday(t *testing.T) {
+	c := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai"})
+
+	// 2024年国庆节放假10月1日至7日
+	nw := c.NextWorkday(time.Date(2024, 9, 30, 9, 0, 0, 0, c.loc))
+	if nw.Format(holidayDateLayout) == "2024-10-08" && nw.Hour() == 9 {
+		t.Log("passed")
+	} else {
+		t.Error(nw)
+	}
+}
+
+func TestCalendar_WorkdaysBetween(t *testing.T) {
+	c := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai"})
+
+	// 2024年2月:春节放假8天,2月4日和2月18日调休上班
+	n := c.WorkdaysBetween(time.Date(2024, 2, 29, 0, 0, 0, 0, c.loc), time.Date(2024, 2, 1, 0, 0, 0, 0, c.loc))
+	if n == 18 {
+		t.Log("passed")
+	} else {
+		t.Error(n)
+	}
+
+	// 2026年春节放假2月15日至23日,2月14日和2月28日调休上班
+	n = c.WorkdaysBetween(time.Date(2026, 2, 1, 0, 0, 0, 0, c.loc), time.Date(2026, 2, 28, 0, 0, 0, 0, c.loc))
+	nw := c.NextWorkday(time.Date(2026, 2, 15, 0, 0, 0, 0, c.loc))
+	if n == 16 && nw.Format(holidayDateLayout) == "2026-02-24" && c.IsWorkday(time.Date(2026, 2, 14, 0, 0, 0, 0, c.loc)) {
+		t.Log("passed")
+	} else {
+		t.Error(n, nw)
+	}
+}
+
+func TestCalendar_DayTypeItem(t *testing.T) {
+	c := NewCalendar(CalendarConfig{Grid: GridDay, TimeZoneName: "Asia/Shanghai", Holiday: true})
+	items := c.GenerateWithDate(2025, 1, 26)
+	if items[0].DayType == DayTypeMakeupWorkday && items[0].DayType.String() == "调休上班" && items[0].DayTypeName == "调休上班" {
+		t.Log("passed")
+	} else {
+		t.Error(items[0].DayType)
+	}
+
+	// 日期类型名称按日历的语言显示
+	c = NewCalendar(CalendarConfig{Grid: GridDay, TimeZoneName: "Asia/Shanghai", Locale: "en", Holiday: true})
+	items = c.GenerateWithDate(2025, 1, 26)
+	if items[0].DayTypeName == "Make-up Workday" && items[0].clone().DayTypeName == "Make-up Workday" {
+		t.Log("passed")
+	} else {
+		t.Error(items[0].DayTypeName)
+	}
+}
+
+// 默认不读取日期类型,json中没有dt
+func TestCalendar_DayTypeDefaultOff(t *testing.T) {
+	c := DefaultCalendar()
+	items := c.GenerateWithDate(2025, 1, 26)
+	b, err := json.Marshal(items[0])
+	if err == nil && items[0].DayType == 0 && !strings.Contains(string(b), `"dt"`) {
+		t.Log("passed")
+	} else {
+		t.Error(items[0].DayType, string(b), err)
+	}
+}
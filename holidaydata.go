@@ -0,0 +1,13 @@
+package gocalendar
+
+import (
+	_ "embed"
+)
+
+// 内置的国务院办公厅节假日安排,数据文件为holidaydata.json
+//
+// 数据格式见 HolidaySchedule ,每年的节假日安排发布后在该文件中追加并修改version,
+// 也可以用 LoadHolidaySchedule 在运行时载入新的节假日安排文件
+//
+//go:embed holidaydata.json
+var holidayScheduleData []byte
@@ -0,0 +1,370 @@
+package gocalendar
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// iCalendar(RFC 5545)中使用的日期时间格式
+const (
+	icalDateLayout     = "20060102"
+	icalDateTimeLayout = "20060102T150405"
+	icalUIDDomain      = "gocalendar"
+)
+
+// type icalEvent struct iCalendar中的一个VEVENT
+type icalEvent struct {
+	uid         string
+	summary     string
+	description string
+	categories  string
+	start       time.Time
+	allDay      bool
+}
+
+// type ICalendar struct iCalendar(RFC 5545)日历
+//
+// 由 (*Calendar) NewICalendar 新建,所有事件使用该Calendar的时区,
+// 同一个日期的同一事件UID总是相同的,重复导出后日历软件会更新而不是重复添加
+type ICalendar struct {
+	Name   string    // 日历名称(X-WR-CALNAME),为空则不输出
+	ProdID string    // PRODID
+	Stamp  time.Time // DTSTAMP,零值时输出时使用当前时间
+
+	c      *Calendar
+	events []icalEvent
+	uids   map[string]bool
+}
+
+// (*Calendar) NewICalendar 新建一个使用c的时区和设置的iCalendar
+func (c *Calendar) NewICalendar() *ICalendar {
+	return &ICalendar{
+		ProdID: "-//liujiawm//gocalendar " + Version + "//ZH",
+		c:      c,
+		uids:   make(map[string]bool),
+	}
+}
+
+// (*ICalendar) AddItems 把日历单元中的节日和农历日期加入iCalendar
+//
+// 公历节日、农历节日和农历日期都是全天事件,节气是带时间的事件。
+// 日历表中补齐首尾周的上一个月和下一个月的日期(IsAccidental不为0)不加入,这些日期可由相邻月份的日历表加入
+func (ic *ICalendar) AddItems(items []*CalendarItem) *ICalendar {
+	for _, item := range items {
+		if item == nil || item.Time == nil || item.IsAccidental != 0 {
+			continue
+		}
+
+		t := item.Time.In(ic.c.loc)
+
+		if item.Festival != nil {
+			ic.addFestivalEvents(t, "festival", item.Festival)
+		}
+
+		if item.LunarDate != nil {
+			ld := item.LunarDate
+			ic.add(icalEvent{
+				uid:         ic.uid("lunar", t.Format(icalDateLayout)),
+				summary:     fmt.Sprintf("%s%s月%s", ld.LeapStr, ld.MonthName, ld.DayName),
+				description: ld.String(),
+				categories:  "农历",
+				start:       t,
+				allDay:      true,
+			})
+
+			if ld.Festival != nil {
+				ic.addFestivalEvents(t, "lunar-festival", ld.Festival)
+			}
+		}
+
+		if item.SolarTerm != nil {
+			ic.AddSolarTerms([]*SolarTermItem{item.SolarTerm})
+		}
+	}
+
+	return ic
+}
+
+// (*ICalendar) AddSolarTerms 把节气加入iCalendar
+//
+// 节气是带时间的事件,时间为 SolarTermItem.Time
+func (ic *ICalendar) AddSolarTerms(sts []*SolarTermItem) *ICalendar {
+	for _, st := range sts {
+		if st == nil || st.Time == nil {
+			continue
+		}
+
+		t := st.Time.In(ic.c.loc)
+		ic.add(icalEvent{
+			uid:         ic.uid("st", fmt.Sprintf("%s-%d", t.Format(icalDateLayout), st.Index)),
+			summary:     st.Name,
+			description: st.String(),
+			categories:  "节气",
+			start:       t,
+		})
+	}
+
+	return ic
+}
+
+// (*ICalendar) AddFestivals 把公历year年的全部公历节日和农历节日加入iCalendar
+//
+// 节日取自该Calendar使用的节日表
+func (ic *ICalendar) AddFestivals(year int) *ICalendar {
+	c := ic.c
+	for t := time.Date(year, 1, 1, 0, 0, 0, 0, c.loc); t.Year() == year; t = t.AddDate(0, 0, 1) {
+		gf := c.gregorianFestival(t)
+		ic.addFestivalEvents(t, "festival", &gf)
+
+		ld, _ := c.gregorianToLunar(t, true)
+		if ld.Festival != nil {
+			ic.addFestivalEvents(t, "lunar-festival", ld.Festival)
+		}
+	}
+
+	return ic
+}
+
+// (*ICalendar) addFestivalEvents 把某日的节日加入iCalendar
+func (ic *ICalendar) addFestivalEvents(t time.Time, kind string, fi *FestivalItem) {
+	categories := "节日"
+	if kind == "lunar-festival" {
+		categories = "农历节日"
+	}
+
+	var names []string
+	names = append(names, fi.Show...)
+	names = append(names, fi.Secondary...)
+	for _, name := range names {
+		ic.add(icalEvent{
+			uid:        ic.uid(kind, t.Format(icalDateLayout)+"-"+name),
+			summary:    name,
+			categories: categories,
+			start:      t,
+			allDay:     true,
+		})
+	}
+}
+
+// (*ICalendar) add 加入一个事件,UID相同的事件只加入一次
+func (ic *ICalendar) add(e icalEvent) {
+	if ic.uids[e.uid] {
+		return
+	}
+	ic.uids[e.uid] = true
+	ic.events = append(ic.events, e)
+}
+
+// (*ICalendar) uid 用事件类型和内容生成固定的UID
+func (ic *ICalendar) uid(kind, key string) string {
+	sum := sha1.Sum([]byte(kind + "|" + key))
+	return kind + "-" + hex.EncodeToString(sum[:8]) + "@" + icalUIDDomain
+}
+
+// (*ICalendar) Len 事件个数
+func (ic *ICalendar) Len() int {
+	return len(ic.events)
+}
+
+// (*ICalendar) WriteTo 输出iCalendar
+func (ic *ICalendar) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+
+	stamp := ic.Stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+	stampStr := stamp.UTC().Format(icalDateTimeLayout) + "Z"
+
+	// 按开始时间排序,使输出稳定
+	events := make([]icalEvent, len(ic.events))
+	copy(events, ic.events)
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].start.Equal(events[j].start) {
+			return events[i].uid < events[j].uid
+		}
+		return events[i].start.Before(events[j].start)
+	})
+
+	tzid := ic.tzid()
+
+	icalLine(&buf, "BEGIN:VCALENDAR")
+	icalLine(&buf, "VERSION:2.0")
+	icalLine(&buf, "PRODID:"+icalEscape(ic.ProdID))
+	icalLine(&buf, "CALSCALE:GREGORIAN")
+	icalLine(&buf, "METHOD:PUBLISH")
+	if ic.Name != "" {
+		icalLine(&buf, "X-WR-CALNAME:"+icalEscape(ic.Name))
+	}
+	if tzid != "" {
+		icalLine(&buf, "X-WR-TIMEZONE:"+tzid)
+		ic.writeTimezone(&buf, tzid, events)
+	}
+
+	for _, e := range events {
+		icalLine(&buf, "BEGIN:VEVENT")
+		icalLine(&buf, "UID:"+e.uid)
+		icalLine(&buf, "DTSTAMP:"+stampStr)
+		if e.allDay {
+			icalLine(&buf, "DTSTART;VALUE=DATE:"+e.start.Format(icalDateLayout))
+			icalLine(&buf, "DTEND;VALUE=DATE:"+e.start.AddDate(0, 0, 1).Format(icalDateLayout))
+			icalLine(&buf, "TRANSP:TRANSPARENT")
+		} else if tzid != "" {
+			icalLine(&buf, "DTSTART;TZID="+tzid+":"+e.start.Format(icalDateTimeLayout))
+		} else {
+			icalLine(&buf, "DTSTART:"+e.start.UTC().Format(icalDateTimeLayout)+"Z")
+		}
+		icalLine(&buf, "SUMMARY:"+icalEscape(e.summary))
+		if e.description != "" {
+			icalLine(&buf, "DESCRIPTION:"+icalEscape(e.description))
+		}
+		if e.categories != "" {
+			icalLine(&buf, "CATEGORIES:"+icalEscape(e.categories))
+		}
+		icalLine(&buf, "END:VEVENT")
+	}
+
+	icalLine(&buf, "END:VCALENDAR")
+
+	return buf.WriteTo(w)
+}
+
+// (*ICalendar) String iCalendar文本
+func (ic *ICalendar) String() string {
+	var sb strings.Builder
+	_, _ = ic.WriteTo(&sb)
+	return sb.String()
+}
+
+// (*ICalendar) tzid 带时间的事件使用的TZID
+//
+// 使用CalendarConfig.TimeZoneName,UTC和Local(无法确定时区名称)时返回空字符串,事件时间将以UTC输出
+func (ic *ICalendar) tzid() string {
+	name := ic.c.config.TimeZoneName
+	if name == "" || name == "UTC" || name == "Local" {
+		return ""
+	}
+	return name
+}
+
+// (*ICalendar) writeTimezone 输出VTIMEZONE
+//
+// 从时区数据中找出事件所在年份的时差变化(夏令时),没有变化的时区只输出一个STANDARD
+func (ic *ICalendar) writeTimezone(buf *bytes.Buffer, tzid string, events []icalEvent) {
+	loc := ic.c.loc
+
+	minYear, maxYear := time.Now().Year(), time.Now().Year()
+	for i, e := range events {
+		if i == 0 || e.start.Year() < minYear {
+			minYear = e.start.Year()
+		}
+		if i == 0 || e.start.Year() > maxYear {
+			maxYear = e.start.Year()
+		}
+	}
+
+	// 从上一年开始查找,使第一个事件之前的时差也有定义
+	start := time.Date(minYear-1, 1, 1, 0, 0, 0, 0, loc)
+	end := time.Date(maxYear+1, 1, 1, 0, 0, 0, 0, loc)
+	transitions := zoneTransitions(start, end)
+
+	icalLine(buf, "BEGIN:VTIMEZONE")
+	icalLine(buf, "TZID:"+tzid)
+
+	if len(transitions) == 0 {
+		name, offset := start.Zone()
+		icalLine(buf, "BEGIN:STANDARD")
+		icalLine(buf, "DTSTART:19700101T000000")
+		icalLine(buf, "TZOFFSETFROM:"+icalOffset(offset))
+		icalLine(buf, "TZOFFSETTO:"+icalOffset(offset))
+		icalLine(buf, "TZNAME:"+icalEscape(name))
+		icalLine(buf, "END:STANDARD")
+	}
+
+	for _, tr := range transitions {
+		_, from := tr.Add(-time.Second).Zone()
+		name, to := tr.Zone()
+
+		component := "STANDARD"
+		if tr.IsDST() {
+			component = "DAYLIGHT"
+		}
+
+		icalLine(buf, "BEGIN:"+component)
+		// DTSTART为变化前的当地时间
+		icalLine(buf, "DTSTART:"+tr.UTC().Add(time.Duration(from)*time.Second).Format(icalDateTimeLayout))
+		icalLine(buf, "TZOFFSETFROM:"+icalOffset(from))
+		icalLine(buf, "TZOFFSETTO:"+icalOffset(to))
+		icalLine(buf, "TZNAME:"+icalEscape(name))
+		icalLine(buf, "END:"+component)
+	}
+
+	icalLine(buf, "END:VTIMEZONE")
+}
+
+// zoneTransitions 找出start至end之间时区的时差变化时刻
+func zoneTransitions(start, end time.Time) []time.Time {
+	var trs []time.Time
+
+	_, prev := start.Zone()
+	for t := start; t.Before(end); {
+		next := t.Add(24 * time.Hour)
+		if _, o := next.Zone(); o != prev {
+			// 二分查找变化的时刻
+			lo, hi := t, next
+			for hi.Sub(lo) > time.Second {
+				mid := lo.Add(hi.Sub(lo) / 2)
+				if _, mo := mid.Zone(); mo == prev {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			trs = append(trs, hi.Truncate(time.Second))
+			prev = o
+		}
+		t = next
+	}
+
+	return trs
+}
+
+// icalOffset 时差秒数转为iCalendar的UTC-OFFSET格式,如+0800
+func icalOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset%3600/60)
+}
+
+// icalEscape 转义TEXT类型的值
+func icalEscape(s string) string {
+	r := strings.NewReplacer("\\", "\\\\", ";", "\\;", ",", "\\,", "\r\n", "\\n", "\n", "\\n")
+	return r.Replace(s)
+}
+
+// icalLine 输出一行内容,超过75个字节时折行,行尾为CRLF
+func icalLine(buf *bytes.Buffer, line string) {
+	n, limit := 0, 75
+	for len(line) > 0 {
+		_, size := utf8.DecodeRuneInString(line)
+		if n+size > limit {
+			buf.WriteString("\r\n ")
+			n, limit = 0, 74 // 折行后的行首有一个空格
+			continue
+		}
+		buf.WriteString(line[:size])
+		line = line[size:]
+		n += size
+	}
+	buf.WriteString("\r\n")
+}
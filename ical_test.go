@@ -0,0 +1,103 @@
+package gocalendar
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestICalendar(t *testing.T) {
+	c := NewCalendar(CalendarConfig{Grid: GridMonth, TimeZoneName: "Asia/Shanghai", SolarTerms: true, Lunar: true})
+	items := c.GenerateWithDate(2021, 2, 1)
+
+	ic := c.NewICalendar()
+	ic.Name = "农历"
+	ic.Stamp = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	ic.AddItems(items).AddSolarTerms(c.SolarTerms(2021))
+
+	s := ic.String()
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"TZID:Asia/Shanghai\r\n",
+		"TZOFFSETTO:+0800\r\n",
+		"DTSTART;VALUE=DATE:20210212\r\nDTEND;VALUE=DATE:20210213\r\n",
+		"SUMMARY:春节\r\n",
+		"DTSTART;TZID=Asia/Shanghai:20210203T225923\r\n",
+		"SUMMARY:立春\r\n",
+		"DTSTAMP:20210101T000000Z\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(s, want) {
+			t.Error(want)
+		}
+	}
+
+	// 日历表首尾补齐的其它月份日期不加入
+	for _, item := range items {
+		uid := "UID:" + ic.uid("lunar", item.Time.Format(icalDateLayout)) + "\r\n"
+		if strings.Contains(s, uid) != (item.IsAccidental == 0) {
+			t.Error(item.Time.Format("2006-01-02"), item.IsAccidental)
+		}
+	}
+
+	// 节气在AddItems和AddSolarTerms中只加入一次
+	if strings.Count(s, "SUMMARY:立春\r\n") != 1 {
+		t.Error(strings.Count(s, "SUMMARY:立春\r\n"))
+	}
+
+	// UID固定
+	if s == ic.String() && strings.Contains(s, "UID:"+ic.uid("festival", "20210214-情人节")+"\r\n") {
+		t.Log("passed")
+	} else {
+		t.Error("UID")
+	}
+
+	for _, line := range strings.Split(s, "\r\n") {
+		if len(line) > 75 {
+			t.Error(line)
+		}
+	}
+}
+
+func TestICalendar_DST(t *testing.T) {
+	c := NewCalendar(CalendarConfig{TimeZoneName: "Europe/Berlin"})
+	ic := c.NewICalendar().AddSolarTerms(c.SolarTerms(2021))
+
+	s := ic.String()
+	if strings.Contains(s, "BEGIN:DAYLIGHT\r\nDTSTART:20210328T020000\r\nTZOFFSETFROM:+0100\r\nTZOFFSETTO:+0200\r\n") &&
+		strings.Contains(s, "BEGIN:STANDARD\r\nDTSTART:20211031T030000\r\nTZOFFSETFROM:+0200\r\nTZOFFSETTO:+0100\r\n") {
+		t.Log("passed")
+	} else {
+		t.Error(s)
+	}
+}
+
+func TestICalendar_AddFestivals(t *testing.T) {
+	c := NewCalendar(CalendarConfig{TimeZoneName: "UTC"})
+	s := c.NewICalendar().AddFestivals(2021).String()
+
+	if strings.Contains(s, "SUMMARY:中秋节\r\n") && strings.Contains(s, "DTSTART;VALUE=DATE:20210921\r\n") && !strings.Contains(s, "VTIMEZONE") {
+		t.Log("passed")
+	} else {
+		t.Error(s)
+	}
+}
+
+func TestICalLine(t *testing.T) {
+	var buf bytes.Buffer
+	line := "DESCRIPTION:" + strings.Repeat("农历", 30)
+	icalLine(&buf, line)
+
+	s := strings.TrimSuffix(buf.String(), "\r\n")
+	for _, l := range strings.Split(s, "\r\n") {
+		if len(l) > 75 {
+			t.Error(len(l), l)
+		}
+	}
+	if strings.Replace(s, "\r\n ", "", -1) == line {
+		t.Log("passed")
+	} else {
+		t.Error(s)
+	}
+}
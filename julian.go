@@ -262,3 +262,11 @@ func julianThousandYear(jd float64) float64 {
 func julianDayFromJ2000(jd float64) float64 {
 	return jd - cJulianDayJ2000
 }
+
+// timeToJd 时间t(按UTC)的儒略日
+func timeToJd(t time.Time) float64 {
+	t = t.UTC()
+	year, month, day := t.Date()
+	hour, minute, second := t.Clock()
+	return JulianDay(float64(year), float64(month), float64(day), float64(hour), float64(minute), float64(second), float64(t.Nanosecond())/1e6)
+}
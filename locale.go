@@ -0,0 +1,182 @@
+package gocalendar
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// 默认语言
+const DefaultLocaleName = "zh-Hans"
+
+// type Locale struct 名称和显示格式的本地化表
+//
+// 节日名称来自节日表,不在此翻译;黄历和八字的术语(建除、十神及日主、纳音等)没有通行的译名,不翻译
+type Locale struct {
+	Name string // 语言名称,如"zh-Hans"、"en"
+
+	Weekdays        [7]string  // 星期,周日为0
+	LunarMonths     [12]string // 农历月份,正月为0
+	LunarDays       [30]string // 农历日,初一为0
+	LunarLeap       string     // 农历闰月标志
+	HeavenlyStems   [10]string // 天干
+	EarthlyBranches [12]string // 地支
+	Animals         [12]string // 生肖
+	Cat             string     // 越南农历卯年的生肖(猫),为空则用Animals
+	StarSigns       [12]string // 星座,水瓶座为0
+	SolarTerms      [24]string // 节气,春分为0
+	MoonPhases      [8]string  // 月相,新月为0
+	DayTypes        [5]string  // 日期类型,索引为DayType
+	HijriMonths     [12]string // 伊斯兰历月份,1月为0
+	HebrewMonths    [14]string // 希伯来历月份,尼散月为0,12为第二亚达月,13为闰年的第一亚达月
+	PersianMonths   [12]string // 波斯历月份,法尔瓦丁月为0
+
+	GZSeparator       string // 干与支之间的分隔符
+	GZFormat          string // 干支显示格式,参数依次为年、月、日、时的干支
+	GZDateFormat      string // 不含时柱的干支显示格式,参数依次为年、月、日的干支
+	LunarDateFormat   string // 农历显示格式,参数依次为年(int)、年干支、生肖、闰月标志、月份名称、日名称
+	SolarTermFormat   string // 节气显示格式,参数依次为节气名称、时间
+	HijriDateFormat   string // 伊斯兰历显示格式,参数依次为年(int)、月份名称、日(int)
+	HebrewDateFormat  string // 希伯来历显示格式,参数依次为年(int)、月份名称、日(int)
+	PersianDateFormat string // 波斯历显示格式,参数依次为年(int)、月份名称、日(int)
+}
+
+var (
+	// 已注册的语言
+	locales = map[string]*Locale{}
+
+	// 保护locales
+	localesMu sync.RWMutex
+)
+
+func init() {
+	for _, l := range builtinLocales() {
+		locales[l.Name] = l
+	}
+}
+
+// RegisterLocale 注册语言,已有同名语言时替换
+//
+// 注册的是l的副本,注册后再修改l不影响已注册的语言;显示格式不能为空。
+// 可以复制内置的语言修改后注册,如:
+//
+//	l := *LookupLocale("en")
+//	l.Name = "en-GB"
+//	l.Animals[7] = "Sheep"
+//	RegisterLocale(&l)
+func RegisterLocale(l *Locale) error {
+	if l == nil || l.Name == "" {
+		return errors.New("语言名称不能为空")
+	}
+
+	formats := []struct {
+		name, format string
+	}{
+		{"GZFormat", l.GZFormat},
+		{"GZDateFormat", l.GZDateFormat},
+		{"LunarDateFormat", l.LunarDateFormat},
+		{"SolarTermFormat", l.SolarTermFormat},
+		{"HijriDateFormat", l.HijriDateFormat},
+		{"HebrewDateFormat", l.HebrewDateFormat},
+		{"PersianDateFormat", l.PersianDateFormat},
+	}
+	for _, f := range formats {
+		if f.format == "" {
+			return fmt.Errorf("语言%s的%s不能为空", l.Name, f.name)
+		}
+	}
+
+	cp := *l
+
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	locales[cp.Name] = &cp
+	return nil
+}
+
+// LookupLocale 按名称取已注册语言的副本,没有则返回nil
+//
+// 修改副本不影响已注册的语言,需用RegisterLocale重新注册
+func LookupLocale(name string) *Locale {
+	l := lookupLocale(name)
+	if l == nil {
+		return nil
+	}
+	cp := *l
+	return &cp
+}
+
+// lookupLocale 按名称取已注册的语言,返回的是共享的表,不能修改
+func lookupLocale(name string) *Locale {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	return locales[name]
+}
+
+// Locales 已注册的语言名称,按名称排列
+func Locales() []string {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+
+	var names []string
+	for name := range locales {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// defaultLocale 默认语言
+func defaultLocale() *Locale {
+	return lookupLocale(DefaultLocaleName)
+}
+
+// orDefault l为nil时返回默认语言
+func (l *Locale) orDefault() *Locale {
+	if l == nil {
+		return defaultLocale()
+	}
+	return l
+}
+
+// (*Locale) gz 干支名称
+func (l *Locale) gz(gzi *GZItem) string {
+	if gzi == nil {
+		return ""
+	}
+	return gzi.HSN + l.GZSeparator + gzi.EBN
+}
+
+// (*Locale) gzItem 用干支索引生成该语言的干支
+func (l *Locale) gzItem(hsi, ebi int) *GZItem {
+	return &GZItem{
+		HSI: hsi,
+		HSN: l.HeavenlyStems[hsi],
+		EBI: ebi,
+		EBN: l.EarthlyBranches[ebi],
+	}
+}
+
+// (*Locale) DayType 日期类型在该语言中的名称
+//
+// DayType本身不带语言,其String方法使用默认语言,日历单元中按所配置语言的名称为CalendarItem.DayTypeName
+func (l *Locale) DayType(dt DayType) string {
+	if dt < 0 || int(dt) >= len(l.DayTypes) {
+		return ""
+	}
+	return l.DayTypes[dt]
+}
+
+// (*Locale) lunarDate 农历显示
+func (l *Locale) lunarDate(ld LunarDate) string {
+	return fmt.Sprintf(l.LunarDateFormat, ld.Year, l.gz(ld.YearGZ), ld.AnimalName, ld.LeapStr, ld.MonthName, ld.DayName)
+}
+
+// (*Calendar) locale 日历使用的语言
+func (c *Calendar) locale() *Locale {
+	if l := lookupLocale(c.config.Locale); l != nil {
+		return l
+	}
+	return defaultLocale()
+}
@@ -0,0 +1,126 @@
+package gocalendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLocales(t *testing.T) {
+	names := strings.Join(Locales(), ",")
+	if names == "en,ja,ko,vi,zh-Hans,zh-Hant" {
+		t.Log("passed")
+	} else {
+		t.Error(names)
+	}
+
+	// 内置语言的名称不能为空
+	for _, name := range Locales() {
+		l := LookupLocale(name)
+		for i, v := range l.SolarTerms {
+			if v == "" {
+				t.Error(name, "SolarTerms", i)
+			}
+		}
+		for i, v := range l.LunarDays {
+			if v == "" {
+				t.Error(name, "LunarDays", i)
+			}
+		}
+	}
+
+	if err := RegisterLocale(&Locale{}); err != nil {
+		t.Log("passed")
+	} else {
+		t.Error("空名称的语言不应注册成功")
+	}
+}
+
+func TestCalendar_Locale(t *testing.T) {
+	tm := time.Date(2021, 5, 6, 12, 0, 0, 0, time.UTC)
+
+	c := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai", Locale: "en"})
+	gz := c.ChineseSexagenaryCycle(tm.In(c.loc))
+	if gz.String() == "Xin-Chou year Gui-Si month Jia-Yin day Jia-Xu hour" {
+		t.Log("passed")
+	} else {
+		t.Error(gz.String())
+	}
+
+	ld := c.GregorianToLunar(2021, 5, 6)
+	if ld.String() == "Month 3 Day 25, 2021 Xin-Chou (Ox)" {
+		t.Log("passed")
+	} else {
+		t.Error(ld.String())
+	}
+
+	c = NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai", Grid: GridMonth, Locale: "zh-Hant", SolarTerms: true, StarSign: true, Lunar: true})
+	items := c.GenerateWithDate(2021, 12, 1)
+	for _, item := range items {
+		if item.Time.Format("2006-01-02") != "2021-12-21" {
+			continue
+		}
+		if item.SolarTerm != nil && item.SolarTerm.Name == "冬至" && item.StarSign.Name == "射手" && item.LunarDate.MonthName == "十一" &&
+			strings.Contains(item.String(), "週二") {
+			t.Log("passed")
+		} else {
+			t.Error(item)
+		}
+	}
+
+	// 未注册的语言使用默认语言
+	c = NewCalendar(CalendarConfig{Locale: "xx"})
+	if c.GetConfig().Locale == DefaultLocaleName {
+		t.Log("passed")
+	} else {
+		t.Error(c.GetConfig().Locale)
+	}
+
+	if LookupLocale("ko").DayType(DayTypeHoliday) == "공휴일" && DayTypeHoliday.String() == "节假日" {
+		t.Log("passed")
+	} else {
+		t.Error(LookupLocale("ko").DayType(DayTypeHoliday))
+	}
+}
+
+func TestRegisterLocale(t *testing.T) {
+	l := *LookupLocale("en")
+	l.Name = "en-GB"
+	l.Animals[7] = "Sheep"
+	if err := RegisterLocale(&l); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai", Locale: "en-GB"})
+	ld := c.GregorianToLunar(2015, 6, 1)
+	if ld.AnimalName == "Sheep" && LookupLocale("en").Animals[7] == "Goat" {
+		t.Log("passed")
+	} else {
+		t.Error(ld.AnimalName)
+	}
+
+	// 注册的是副本,之后修改l不影响已注册的语言
+	l.Animals[7] = "Ram"
+	if LookupLocale("en-GB").Animals[7] == "Sheep" {
+		t.Log("passed")
+	} else {
+		t.Error(LookupLocale("en-GB").Animals[7])
+	}
+
+	// LookupLocale返回的也是副本,修改后不影响已注册的语言
+	LookupLocale("en").Animals[7] = "Ram"
+	if LookupLocale("en").Animals[7] == "Goat" {
+		t.Log("passed")
+	} else {
+		t.Error(LookupLocale("en").Animals[7])
+	}
+
+	// 显示格式不能为空
+	l.Name = "en-XX"
+	l.LunarDateFormat = ""
+	if err := RegisterLocale(&l); err != nil && LookupLocale("en-XX") == nil {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,206 @@
+package gocalendar
+
+import "strconv"
+
+// builtinLocales 内置的语言:zh-Hans、zh-Hant、en、ja、ko、vi
+func builtinLocales() []*Locale {
+	zhHans := &Locale{
+		Name:              "zh-Hans",
+		LunarMonths:       lunarMonthNameArray,
+		LunarLeap:         lunarLeapString,
+		HeavenlyStems:     heavenlyStemsNameArray,
+		EarthlyBranches:   earthlyBranchesNameArray,
+		Animals:           symbolicAnimalsNameArray,
+		Cat:               "猫",
+		StarSigns:         starSignsNameArray,
+		SolarTerms:        solarTermsNameArray,
+		MoonPhases:        moonPhaseNameArray,
+		DayTypes:          dayTypeNameArray,
+		GZFormat:          "%s年%s月%s日%s时",
+		GZDateFormat:      "%s年%s月%s日",
+		LunarDateFormat:   "%[1]d%[2]s(%[3]s)年%[4]s%[5]s月%[6]s",
+		SolarTermFormat:   "%[1]s 定%[1]s:%[2]s",
+		HijriMonths:       hijriMonthNameArray,
+		HijriDateFormat:   "%[1]d年%[2]s%[3]d日",
+		HebrewMonths:      hebrewMonthNameArray,
+		HebrewDateFormat:  "%[1]d年%[2]s%[3]d日",
+		PersianMonths:     persianMonthNameArray,
+		PersianDateFormat: "%[1]d年%[2]s%[3]d日",
+	}
+	for i, v := range weekNameArray {
+		zhHans.Weekdays[i] = "周" + v
+	}
+	for i := range zhHans.LunarDays {
+		zhHans.LunarDays[i] = DayChinese(i + 1)
+	}
+
+	zhHant := &Locale{
+		Name:            "zh-Hant",
+		Weekdays:        [7]string{"週日", "週一", "週二", "週三", "週四", "週五", "週六"},
+		LunarMonths:     [12]string{"正", "二", "三", "四", "五", "六", "七", "八", "九", "十", "十一", "臘"},
+		LunarDays:       zhHans.LunarDays,
+		LunarLeap:       "閏",
+		HeavenlyStems:   heavenlyStemsNameArray,
+		EarthlyBranches: earthlyBranchesNameArray,
+		Animals:         [12]string{"鼠", "牛", "虎", "兔", "龍", "蛇", "馬", "羊", "猴", "雞", "狗", "豬"},
+		Cat:             "貓",
+		StarSigns:       [12]string{"水瓶", "雙魚", "白羊", "金牛", "雙子", "巨蟹", "獅子", "處女", "天秤", "天蠍", "射手", "摩羯"},
+		SolarTerms: [24]string{"春分", "清明", "穀雨", "立夏", "小滿", "芒種", "夏至", "小暑", "大暑", "立秋", "處暑", "白露",
+			"秋分", "寒露", "霜降", "立冬", "小雪", "大雪", "冬至", "小寒", "大寒", "立春", "雨水", "驚蟄"},
+		MoonPhases:      [8]string{"新月", "蛾眉月", "上弦月", "盈凸月", "滿月", "虧凸月", "下弦月", "殘月"},
+		DayTypes:        [5]string{"", "工作日", "週末", "節假日", "調休上班"},
+		GZFormat:        "%s年%s月%s日%s時",
+		GZDateFormat:    "%s年%s月%s日",
+		LunarDateFormat: "%[1]d%[2]s(%[3]s)年%[4]s%[5]s月%[6]s",
+		SolarTermFormat: "%[1]s 定%[1]s:%[2]s",
+		HijriMonths: [12]string{"穆哈蘭姆月", "色法爾月", "賴比爾·敖外魯月", "賴比爾·阿色尼月", "主馬達·敖外魯月", "主馬達·阿色尼月",
+			"賴哲卜月", "舍爾邦月", "賴買丹月", "閃瓦魯月", "都爾喀爾德月", "都爾黑哲月"},
+		HijriDateFormat: "%[1]d年%[2]s%[3]d日",
+		HebrewMonths: [14]string{"尼散月", "以珥月", "西彎月", "搭模斯月", "埃波月", "以祿月", "提斯利月",
+			"瑪西班月", "基斯流月", "提別月", "細罷特月", "亞達月", "亞達二月", "亞達一月"},
+		HebrewDateFormat: "%[1]d年%[2]s%[3]d日",
+		PersianMonths: [12]string{"法爾瓦丁月", "奧爾迪別赫什特月", "霍爾達德月", "提爾月", "莫爾達德月", "沙赫里瓦爾月",
+			"梅赫爾月", "阿班月", "阿扎爾月", "達伊月", "巴赫曼月", "埃斯凡德月"},
+		PersianDateFormat: "%[1]d年%[2]s%[3]d日",
+	}
+
+	en := &Locale{
+		Name:            "en",
+		Weekdays:        [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		LunarLeap:       "Leap ",
+		HeavenlyStems:   [10]string{"Jia", "Yi", "Bing", "Ding", "Wu", "Ji", "Geng", "Xin", "Ren", "Gui"},
+		EarthlyBranches: [12]string{"Zi", "Chou", "Yin", "Mao", "Chen", "Si", "Wu", "Wei", "Shen", "You", "Xu", "Hai"},
+		Animals:         [12]string{"Rat", "Ox", "Tiger", "Rabbit", "Dragon", "Snake", "Horse", "Goat", "Monkey", "Rooster", "Dog", "Pig"},
+		Cat:             "Cat",
+		StarSigns: [12]string{"Aquarius", "Pisces", "Aries", "Taurus", "Gemini", "Cancer",
+			"Leo", "Virgo", "Libra", "Scorpio", "Sagittarius", "Capricorn"},
+		SolarTerms: [24]string{"Spring Equinox", "Clear and Bright", "Grain Rain", "Start of Summer", "Grain Buds", "Grain in Ear",
+			"Summer Solstice", "Minor Heat", "Major Heat", "Start of Autumn", "End of Heat", "White Dew",
+			"Autumn Equinox", "Cold Dew", "Frost's Descent", "Start of Winter", "Minor Snow", "Major Snow",
+			"Winter Solstice", "Minor Cold", "Major Cold", "Start of Spring", "Rain Water", "Awakening of Insects"},
+		MoonPhases: [8]string{"New Moon", "Waxing Crescent", "First Quarter", "Waxing Gibbous",
+			"Full Moon", "Waning Gibbous", "Last Quarter", "Waning Crescent"},
+		DayTypes:        [5]string{"", "Workday", "Weekend", "Holiday", "Make-up Workday"},
+		GZSeparator:     "-",
+		GZFormat:        "%s year %s month %s day %s hour",
+		GZDateFormat:    "%s year %s month %s day",
+		LunarDateFormat: "%[4]sMonth %[5]s Day %[6]s, %[1]d %[2]s (%[3]s)",
+		SolarTermFormat: "%[1]s %[2]s",
+		HijriMonths: [12]string{"Muharram", "Safar", "Rabi' al-Awwal", "Rabi' al-Thani", "Jumada al-Awwal", "Jumada al-Thani",
+			"Rajab", "Sha'ban", "Ramadan", "Shawwal", "Dhu al-Qi'dah", "Dhu al-Hijjah"},
+		HijriDateFormat: "%[3]d %[2]s %[1]d AH",
+		HebrewMonths: [14]string{"Nisan", "Iyyar", "Sivan", "Tammuz", "Av", "Elul", "Tishrei",
+			"Marheshvan", "Kislev", "Tevet", "Shevat", "Adar", "Adar II", "Adar I"},
+		HebrewDateFormat: "%[3]d %[2]s %[1]d",
+		PersianMonths: [12]string{"Farvardin", "Ordibehesht", "Khordad", "Tir", "Mordad", "Shahrivar",
+			"Mehr", "Aban", "Azar", "Dey", "Bahman", "Esfand"},
+		PersianDateFormat: "%[3]d %[2]s %[1]d AP",
+	}
+
+	ja := &Locale{
+		Name:            "ja",
+		Weekdays:        [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		LunarMonths:     [12]string{"正", "二", "三", "四", "五", "六", "七", "八", "九", "十", "十一", "十二"},
+		LunarDays:       zhHans.LunarDays,
+		LunarLeap:       "閏",
+		HeavenlyStems:   heavenlyStemsNameArray,
+		EarthlyBranches: earthlyBranchesNameArray,
+		Animals:         [12]string{"鼠", "牛", "虎", "兎", "竜", "蛇", "馬", "羊", "猿", "鶏", "犬", "猪"},
+		Cat:             "猫",
+		StarSigns: [12]string{"水瓶座", "魚座", "牡羊座", "牡牛座", "双子座", "蟹座",
+			"獅子座", "乙女座", "天秤座", "蠍座", "射手座", "山羊座"},
+		SolarTerms: [24]string{"春分", "清明", "穀雨", "立夏", "小満", "芒種", "夏至", "小暑", "大暑", "立秋", "処暑", "白露",
+			"秋分", "寒露", "霜降", "立冬", "小雪", "大雪", "冬至", "小寒", "大寒", "立春", "雨水", "啓蟄"},
+		MoonPhases:      [8]string{"新月", "三日月", "上弦の月", "十三夜月", "満月", "寝待月", "下弦の月", "有明月"},
+		DayTypes:        [5]string{"", "平日", "週末", "祝日", "振替出勤"},
+		GZFormat:        "%s年%s月%s日%s時",
+		GZDateFormat:    "%s年%s月%s日",
+		LunarDateFormat: "%[1]d%[2]s(%[3]s)年%[4]s%[5]s月%[6]s",
+		SolarTermFormat: "%[1]s %[2]s",
+		HijriMonths: [12]string{"ムハッラム", "サファル", "ラビー・アル＝アウワル", "ラビー・アッ＝サーニー", "ジュマーダー・アル＝ウーラー", "ジュマーダー・アッ＝サーニー",
+			"ラジャブ", "シャアバーン", "ラマダーン", "シャウワール", "ズー・アル＝カアダ", "ズー・アル＝ヒッジャ"},
+		HijriDateFormat: "ヒジュラ暦%[1]d年%[2]s%[3]d日",
+		HebrewMonths: [14]string{"ニサン", "イヤル", "シワン", "タンムズ", "アブ", "エルル", "ティシュリ",
+			"ヘシュワン", "キスレウ", "テベト", "シェバト", "アダル", "アダル・シェニ", "アダル・リション"},
+		HebrewDateFormat: "ユダヤ暦%[1]d年%[2]s%[3]d日",
+		PersianMonths: [12]string{"ファルヴァルディーン", "オルディーベヘシュト", "ホルダード", "ティール", "モルダード", "シャフリーヴァル",
+			"メフル", "アーバーン", "アーザル", "デイ", "バフマン", "エスファンド"},
+		PersianDateFormat: "イラン暦%[1]d年%[2]s%[3]d日",
+	}
+
+	ko := &Locale{
+		Name:            "ko",
+		Weekdays:        [7]string{"일", "월", "화", "수", "목", "금", "토"},
+		LunarLeap:       "윤",
+		HeavenlyStems:   [10]string{"갑", "을", "병", "정", "무", "기", "경", "신", "임", "계"},
+		EarthlyBranches: [12]string{"자", "축", "인", "묘", "진", "사", "오", "미", "신", "유", "술", "해"},
+		Animals:         [12]string{"쥐", "소", "호랑이", "토끼", "용", "뱀", "말", "양", "원숭이", "닭", "개", "돼지"},
+		Cat:             "고양이",
+		StarSigns: [12]string{"물병자리", "물고기자리", "양자리", "황소자리", "쌍둥이자리", "게자리",
+			"사자자리", "처녀자리", "천칭자리", "전갈자리", "궁수자리", "염소자리"},
+		SolarTerms: [24]string{"춘분", "청명", "곡우", "입하", "소만", "망종", "하지", "소서", "대서", "입추", "처서", "백로",
+			"추분", "한로", "상강", "입동", "소설", "대설", "동지", "소한", "대한", "입춘", "우수", "경칩"},
+		MoonPhases:      [8]string{"삭", "초승달", "상현달", "차오르는 달", "보름달", "이지러지는 달", "하현달", "그믐달"},
+		DayTypes:        [5]string{"", "평일", "주말", "공휴일", "대체근무일"},
+		GZFormat:        "%s년 %s월 %s일 %s시",
+		GZDateFormat:    "%s년 %s월 %s일",
+		LunarDateFormat: "%[1]d년 %[2]s(%[3]s) %[4]s%[5]s월 %[6]s일",
+		SolarTermFormat: "%[1]s %[2]s",
+		HijriMonths: [12]string{"무하람", "사파르", "라비 알아왈", "라비 알사니", "주마다 알울라", "주마다 알아키라",
+			"라잡", "샤반", "라마단", "샤왈", "둘카다", "둘히자"},
+		HijriDateFormat: "히즈라력 %[1]d년 %[2]s %[3]d일",
+		HebrewMonths: [14]string{"니산", "이야르", "시반", "탐무즈", "아브", "엘룰", "티슈리",
+			"헤시반", "키슬레브", "테벳", "슈밧", "아다르", "아다르 2월", "아다르 1월"},
+		HebrewDateFormat: "유대력 %[1]d년 %[2]s %[3]d일",
+		PersianMonths: [12]string{"파르바르딘", "오르디베헤슈트", "호르다드", "티르", "모르다드", "샤흐리바르",
+			"메흐르", "아반", "아자르", "데이", "바흐만", "에스판드"},
+		PersianDateFormat: "이란력 %[1]d년 %[2]s %[3]d일",
+	}
+
+	vi := &Locale{
+		Name:            "vi",
+		Weekdays:        [7]string{"CN", "T2", "T3", "T4", "T5", "T6", "T7"},
+		LunarMonths:     [12]string{"Giêng", "Hai", "Ba", "Tư", "Năm", "Sáu", "Bảy", "Tám", "Chín", "Mười", "Một", "Chạp"},
+		LunarLeap:       " nhuận",
+		HeavenlyStems:   [10]string{"Giáp", "Ất", "Bính", "Đinh", "Mậu", "Kỷ", "Canh", "Tân", "Nhâm", "Quý"},
+		EarthlyBranches: [12]string{"Tý", "Sửu", "Dần", "Mão", "Thìn", "Tỵ", "Ngọ", "Mùi", "Thân", "Dậu", "Tuất", "Hợi"},
+		Animals:         [12]string{"Chuột", "Trâu", "Hổ", "Mèo", "Rồng", "Rắn", "Ngựa", "Dê", "Khỉ", "Gà", "Chó", "Lợn"},
+		Cat:             "Mèo",
+		StarSigns: [12]string{"Bảo Bình", "Song Ngư", "Bạch Dương", "Kim Ngưu", "Song Tử", "Cự Giải",
+			"Sư Tử", "Xử Nữ", "Thiên Bình", "Thiên Yết", "Nhân Mã", "Ma Kết"},
+		SolarTerms: [24]string{"Xuân phân", "Thanh minh", "Cốc vũ", "Lập hạ", "Tiểu mãn", "Mang chủng",
+			"Hạ chí", "Tiểu thử", "Đại thử", "Lập thu", "Xử thử", "Bạch lộ",
+			"Thu phân", "Hàn lộ", "Sương giáng", "Lập đông", "Tiểu tuyết", "Đại tuyết",
+			"Đông chí", "Tiểu hàn", "Đại hàn", "Lập xuân", "Vũ thủy", "Kinh trập"},
+		MoonPhases: [8]string{"Trăng mới", "Trăng lưỡi liềm đầu tháng", "Trăng thượng huyền", "Trăng khuyết đầu tháng",
+			"Trăng tròn", "Trăng khuyết cuối tháng", "Trăng hạ huyền", "Trăng lưỡi liềm cuối tháng"},
+		DayTypes:        [5]string{"", "Ngày làm việc", "Cuối tuần", "Ngày lễ", "Ngày làm bù"},
+		GZSeparator:     " ",
+		GZFormat:        "năm %s tháng %s ngày %s giờ %s",
+		GZDateFormat:    "năm %s tháng %s ngày %s",
+		LunarDateFormat: "ngày %[6]s tháng %[5]s%[4]s năm %[2]s (%[3]s) %[1]d",
+		SolarTermFormat: "%[1]s %[2]s",
+		HijriMonths: [12]string{"Muharram", "Safar", "Rabi' al-Awwal", "Rabi' al-Thani", "Jumada al-Awwal", "Jumada al-Thani",
+			"Rajab", "Sha'ban", "Ramadan", "Shawwal", "Dhu al-Qi'dah", "Dhu al-Hijjah"},
+		HijriDateFormat: "ngày %[3]d %[2]s năm %[1]d AH",
+		HebrewMonths: [14]string{"Nisan", "Iyyar", "Sivan", "Tammuz", "Av", "Elul", "Tishrei",
+			"Marheshvan", "Kislev", "Tevet", "Shevat", "Adar", "Adar II", "Adar I"},
+		HebrewDateFormat: "ngày %[3]d %[2]s năm %[1]d",
+		PersianMonths: [12]string{"Farvardin", "Ordibehesht", "Khordad", "Tir", "Mordad", "Shahrivar",
+			"Mehr", "Aban", "Azar", "Dey", "Bahman", "Esfand"},
+		PersianDateFormat: "ngày %[3]d %[2]s năm %[1]d AP",
+	}
+
+	// 英文、韩文和越南文的农历月日用数字
+	for i := range en.LunarMonths {
+		en.LunarMonths[i] = strconv.Itoa(i + 1)
+		ko.LunarMonths[i] = strconv.Itoa(i + 1)
+	}
+	for i := range en.LunarDays {
+		en.LunarDays[i] = strconv.Itoa(i + 1)
+		ko.LunarDays[i] = strconv.Itoa(i + 1)
+		vi.LunarDays[i] = strconv.Itoa(i + 1)
+	}
+
+	return []*Locale{zhHans, zhHant, en, ja, ko, vi}
+}
@@ -0,0 +1,192 @@
+package gocalendar
+
+//go:generate go run gen_lunartable.go
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// 农历表每年的字节数
+//
+// 每年3个字节共24位,从高位起:
+// 6位 正月初一距公历该年1月1日的天数;
+// 4位 闰几月,0无闰月;
+// 1位 未用;
+// 13位 各月是否大月(30天),最低位为正月,闰月排在同名平月之后
+const cLunarTableYearBytes = 3
+
+// type lunarYearInfo struct 农历表中一个农历年的数据
+type lunarYearInfo struct {
+	newYear int     // 正月初一的儒略日数(JDN)
+	leap    int     // 闰几月,0无闰月
+	count   int     // 该年的月数,12或13
+	days    [13]int // 各月天数,闰月排在同名平月之后
+}
+
+var (
+	// 解码后的农历表,索引为农历年减lunarTableStartYear
+	lunarTable     []lunarYearInfo
+	lunarTableOnce sync.Once
+)
+
+// jdnOfDate 公历日期的儒略日数(JDN)
+func jdnOfDate(year, month, day int) int {
+	return int(JulianDay(float64(year), float64(month), float64(day)) + 0.5)
+}
+
+// decodeLunarTable 解码农历表数据
+func decodeLunarTable(data string, startYear int) []lunarYearInfo {
+	n := len(data) / cLunarTableYearBytes
+	table := make([]lunarYearInfo, n)
+	for i := 0; i < n; i++ {
+		b := data[i*cLunarTableYearBytes : (i+1)*cLunarTableYearBytes]
+		v := int(b[0])<<16 | int(b[1])<<8 | int(b[2])
+
+		info := &table[i]
+		info.newYear = jdnOfDate(startYear+i, 1, 1) + v>>18
+		info.leap = v >> 14 & 0xf
+		info.count = 12
+		if info.leap > 0 {
+			info.count = 13
+		}
+		for j := 0; j < info.count; j++ {
+			info.days[j] = 29 + v>>uint(j)&1
+		}
+	}
+	return table
+}
+
+// (*lunarYearInfo) monthIndex 农历月份在该年的顺序,正月为0
+func (info *lunarYearInfo) monthIndex(lunarMonth int, isLeap bool) (int, error) {
+	if isLeap {
+		if info.leap == 0 || info.leap != lunarMonth {
+			return 0, ErrNoLeapMonth
+		}
+		return lunarMonth, nil
+	}
+	if info.leap > 0 && lunarMonth > info.leap {
+		return lunarMonth, nil
+	}
+	return lunarMonth - 1, nil
+}
+
+// (*lunarYearInfo) jdn 该年农历日期的儒略日数(JDN)
+func (info *lunarYearInfo) jdn(lunarMonth, lunarDay int, isLeap bool) (int, error) {
+	mi, err := info.monthIndex(lunarMonth, isLeap)
+	if err != nil {
+		return 0, err
+	}
+	if lunarDay > info.days[mi] {
+		return 0, ErrDayOutOfMonth
+	}
+
+	jdn := info.newYear
+	for i := 0; i < mi; i++ {
+		jdn += info.days[i]
+	}
+	return jdn + lunarDay - 1, nil
+}
+
+// LunarTableRange 内置农历表覆盖的农历年份
+func LunarTableRange() (startYear, endYear int) {
+	return lunarTableStartYear, lunarTableEndYear
+}
+
+// (*Calendar) lunarTableYear 启用农历表且表中有该农历年时返回该年的数据
+//
+// 农历表按中国农历生成,韩国和越南农历不查表
+func (c *Calendar) lunarTableYear(lunarYear int) (*lunarYearInfo, bool) {
+	if !c.config.LunarTable || c.config.LunarVariant != ChineseLunar || lunarYear < lunarTableStartYear || lunarYear > lunarTableEndYear {
+		return nil, false
+	}
+
+	lunarTableOnce.Do(func() {
+		lunarTable = decodeLunarTable(lunarTableData, lunarTableStartYear)
+	})
+	return &lunarTable[lunarYear-lunarTableStartYear], true
+}
+
+// (*Calendar) tableGregorianToLunar 用农历表将公历日期转为农历,表中没有时ok为false
+func (c *Calendar) tableGregorianToLunar(year, month, day int) (lunarYear, lunarMonth, lunarDay int, isLeap, ok bool) {
+	jdn := jdnOfDate(year, month, day)
+
+	info, ok := c.lunarTableYear(year)
+	if ok && jdn < info.newYear {
+		info, ok = c.lunarTableYear(year - 1)
+		year--
+	}
+	if !ok {
+		return 0, 0, 0, false, false
+	}
+
+	d := jdn - info.newYear
+	mi := 0
+	for mi < info.count-1 && d >= info.days[mi] {
+		d -= info.days[mi]
+		mi++
+	}
+
+	lunarMonth = mi + 1
+	if info.leap > 0 && mi >= info.leap {
+		lunarMonth = mi
+		isLeap = mi == info.leap
+	}
+
+	return year, lunarMonth, d + 1, isLeap, true
+}
+
+// BuildLunarTable 用天文算法计算startYear至endYear农历年的农历表数据
+//
+// 内置的农历表由gen_lunartable.go调用该函数生成
+func BuildLunarTable(startYear, endYear int) ([]byte, error) {
+	if startYear > endYear || checkLunarYear(startYear) != nil || checkLunarYear(endYear) != nil {
+		return nil, ErrOutOfRange
+	}
+
+	c := NewCalendar(CalendarConfig{TimeZoneName: "UTC"})
+
+	var data []byte
+	for y := startYear; y <= endYear; y++ {
+		t, err := c.LunarToGregorian(y, 1, 1, false)
+		if err != nil {
+			return nil, err
+		}
+
+		offset := jdnOfDate(t.Year(), int(t.Month()), t.Day()) - jdnOfDate(y, 1, 1)
+		if t.Year() != y || offset >= 1<<6 {
+			return nil, errors.New("农历正月初一超出农历表的表示范围")
+		}
+
+		leap := c.LunarLeap(y)
+		v := offset<<18 | leap<<14
+
+		mi := 0
+		for m := 1; m <= 12; m++ {
+			for _, isLeap := range []bool{false, true} {
+				if isLeap && m != leap {
+					continue
+				}
+				days, err := c.LunarMonthDays(y, m, isLeap)
+				if err != nil {
+					return nil, err
+				}
+				if days == 30 {
+					v |= 1 << uint(mi)
+				}
+				mi++
+			}
+		}
+
+		data = append(data, byte(v>>16), byte(v>>8), byte(v))
+	}
+
+	return data, nil
+}
+
+// lunarTableTime 儒略日数(JDN)对应的loc时区该日0时
+func lunarTableTime(jdn int, loc *time.Location) time.Time {
+	tm := JdToTimeMap(float64(jdn))
+	return time.Date(tm["year"], time.Month(tm["month"]), tm["day"], 0, 0, 0, 0, loc)
+}
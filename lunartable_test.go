@@ -0,0 +1,99 @@
+package gocalendar
+
+import (
+	"testing"
+	"time"
+)
+
+// 内置农历表与天文算法的结果一致
+func TestBuildLunarTable(t *testing.T) {
+	data, err := BuildLunarTable(lunarTableStartYear, lunarTableEndYear)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) == lunarTableData {
+		t.Log("passed")
+	} else {
+		t.Error("内置农历表数据与BuildLunarTable的结果不一致,需运行go generate")
+	}
+
+	if _, err := BuildLunarTable(2000, 1999); err == ErrOutOfRange {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+}
+
+func TestCalendar_LunarTable(t *testing.T) {
+	astro := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai"})
+	table := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai", LunarTable: true})
+
+	// 公历转农历,逐日比较
+	bad := 0
+	start := time.Date(lunarTableStartYear, 2, 1, 0, 0, 0, 0, astro.loc)
+	end := time.Date(lunarTableEndYear, 12, 31, 0, 0, 0, 0, astro.loc)
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		a, _ := astro.gregorianToLunar(d, false)
+		b, _ := table.gregorianToLunar(d, false)
+		if a.Year != b.Year || a.Month != b.Month || a.Day != b.Day || a.LeapStr != b.LeapStr {
+			bad++
+			if bad <= 5 {
+				t.Error(d.Format("2006-01-02"), a, b)
+			}
+		}
+	}
+	if bad == 0 {
+		t.Log("passed")
+	}
+
+	// 农历转公历和每月天数
+	for y := lunarTableStartYear; y <= lunarTableEndYear; y++ {
+		if astro.LunarLeap(y) != table.LunarLeap(y) {
+			t.Error(y, astro.LunarLeap(y), table.LunarLeap(y))
+		}
+		for m := 1; m <= 12; m++ {
+			for _, isLeap := range []bool{false, true} {
+				ad, aerr := astro.LunarMonthDays(y, m, isLeap)
+				bd, berr := table.LunarMonthDays(y, m, isLeap)
+				if ad != bd || (aerr == nil) != (berr == nil) {
+					t.Error(y, m, isLeap, ad, bd, aerr, berr)
+					continue
+				}
+				if aerr != nil {
+					continue
+				}
+				for _, day := range []int{1, ad, ad + 1} {
+					at, aerr := astro.LunarToGregorian(y, m, day, isLeap)
+					bt, berr := table.LunarToGregorian(y, m, day, isLeap)
+					if !at.Equal(bt) || (aerr == nil) != (berr == nil) {
+						t.Error(y, m, day, isLeap, at, bt, aerr, berr)
+					}
+				}
+			}
+		}
+	}
+
+	// 农历表以外的年份按天文算法计算
+	a := astro.GregorianToLunar(1800, 6, 1)
+	b := table.GregorianToLunar(1800, 6, 1)
+	if a.Month == b.Month && a.Day == b.Day {
+		t.Log("passed")
+	} else {
+		t.Error(a, b)
+	}
+}
+
+func BenchmarkCalendar_GregorianToLunar(b *testing.B) {
+	for _, lt := range []bool{false, true} {
+		c := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai", LunarTable: lt, AstroCache: NewAstroCache(16)})
+		name := "astronomy"
+		if lt {
+			name = "table"
+		}
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				c.GregorianToLunar(1900+i%200, 1+i%12, 1+i%28)
+			}
+		})
+	}
+}
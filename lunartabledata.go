@@ -0,0 +1,33 @@
+// Code generated by gen_lunartable.go; DO NOT EDIT.
+
+package gocalendar
+
+// 内置农历表覆盖的农历年份
+const (
+	lunarTableStartYear = 1900
+	lunarTableEndYear   = 2100
+)
+
+// 内置农历表数据,每年3个字节,格式见cLunarTableYearBytes
+const lunarTableData = "" +
+	"\x7a\x16\xd2\xc4\x07\x52\x98\x0e\xa5\x71\x56\x4a\xb8\x06\x4b\x88\x0a\x9b\x61\x15\x56\xac\x05\x6a\x80\x0b\x59\x54\x97\x52" +
+	"\xa0\x07\x52\x75\x9b\x25\xc0\x0b\x25\x90\x0a\x4b\x65\x52\xab\xb0\x0a\xad\x88\x05\x6a\x58\x8b\x69\xa4\x0d\xa9\x7d\xdd\x92" +
+	"\xc8\x0d\x92\x98\x0d\x25\x6d\x5a\x4d\xb8\x0a\x56\x8c\x02\xb6\x5d\x15\xb5\xac\x06\xd4\x80\x0e\xa9\x58\x9e\x92\xa0\x0e\x92" +
+	"\x75\x8d\x26\xbc\x05\x2b\x90\x0a\x57\x65\x52\xb6\xb0\x0b\x5a\x88\x06\xd4\x5c\xce\xc9\xa4\x07\x49\x79\xd6\x93\xc4\x0a\x93" +
+	"\x98\x05\x2b\x69\x8a\x5b\xb4\x0a\xad\x8c\x05\x6a\x61\x1b\x55\xac\x0b\xa4\x80\x0b\x49\x54\x9a\x93\xa0\x0a\x95\x71\xd5\x2d" +
+	"\xbc\x05\x36\x90\x0a\xad\x69\x55\xaa\xb0\x05\xb2\x84\x0d\xa5\x5c\xdd\x4a\xa8\x0d\x4a\x7a\x0a\x95\xc0\x0a\x97\x98\x05\x56" +
+	"\x6d\x8a\xb5\xb4\x0a\xd5\x8c\x06\xd2\x61\x0e\xa5\xac\x0e\xa5\x80\x06\x4a\x50\xcc\x97\x9c\x0a\x9b\x75\xd5\x5a\xbc\x05\x6a" +
+	"\x90\x0b\x69\x69\x57\x52\xb4\x0b\x52\x84\x0b\x25\x59\x16\x4b\xa4\x0a\x4b\x7a\x14\xab\xc0\x02\xad\x94\x05\x6d\x6d\x8b\x69" +
+	"\xb8\x0d\xa9\x8c\x0d\x92\x61\x1d\x25\xac\x0d\x25\x82\x9a\x4d\xc8\x0a\x56\x9c\x02\xb6\x71\x85\xb5\xbc\x06\xd5\x90\x0e\xa9" +
+	"\x69\x5e\x92\xb4\x0e\x92\x88\x0d\x26\x58\xca\x56\xa0\x0a\x57\x7a\x14\xd6\xc4\x03\x5a\x94\x06\xd5\x6d\x56\xc9\xb8\x07\x49" +
+	"\x8c\x06\x93\x5d\x15\x2b\xa8\x05\x2b\x7c\x0a\x5b\x54\x95\x5a\x9c\x05\x6a\x71\xdb\x55\xc0\x0b\xa4\x94\x0b\x49\x65\x5a\x93" +
+	"\xb0\x0a\x95\x84\x05\x2d\x59\x0a\xad\xa0\x0a\xb5\x7a\x55\xaa\xc4\x05\xd2\x98\x0d\xa5\x6d\x9d\x4a\xb8\x0d\x4a\x8c\x0c\x95" +
+	"\x61\x15\x2e\xa8\x05\x56\x7c\x0a\xb5\x54\x95\xb2\xa0\x06\xd2\x71\x8e\xa5\xbc\x07\x25\x90\x06\x4b\x65\x4c\x97\xac\x0c\xab" +
+	"\x84\x05\x5a\x58\xca\xd6\xa4\x0b\x69\x7a\xd7\x52\xc4\x0b\x52\x98\x0b\x25\x6d\x9a\x4b\xb4\x0a\x4b\x88\x04\xab\x5d\x45\x5b" +
+	"\xa8\x05\xad\x7c\x0b\x6a\x54\x9b\x52\xa0\x0d\x92\x75\xdd\x25\xbc\x0d\x25\x90\x0a\x55\x65\x54\xad\xb0\x04\xb6\x80\x05\xb5" +
+	"\x58\xcd\xaa\xa4\x0e\xc9\x7e\x1e\x92\xc4\x0e\x92\x98\x0d\x26\x6d\x8a\x56\xb4\x0a\x57\x88\x05\x56\x5d\x06\xd5\xa8\x07\x55" +
+	"\x80\x07\x49\x50\xce\x93\x9c\x06\x93\x71\xd5\x2b\xbc\x05\x2b\x8c\x0a\x5b\x65\x55\x5a\xb0\x05\x6a\x84\x0b\x65\x59\x17\x4a" +
+	"\xa4\x0b\x4a\x7a\x1a\x95\xc4\x0a\x95\x94\x05\x2d\x69\x8a\xad\xb4\x0a\xb5\x8c\x05\xaa\x5d\x0b\xa5\xa8\x0d\xa5\x80\x0d\x4a" +
+	"\x54\xdc\x95\x9c\x0c\x96\x71\xd9\x4e\xbc\x05\x56\x90\x0a\xb5\x65\x55\xb2\xb0\x06\xd2\x84\x0e\xa5\x5d\x0e\x4a\xa0\x06\x8b" +
+	"\x76\x0c\x97\xc0\x04\xab\x94\x05\x5b\x69\x8a\xd6\xb4\x0b\x6a\x8c\x07\x52\x61\x17\x25\xa8\x0b\x45\x7c\x0a\x8b\x50\x94\x9b" +
+	"\x9c\x04\xab"
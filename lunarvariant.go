@@ -0,0 +1,84 @@
+package gocalendar
+
+// 农历的变体,规则与中国农历相同,按各自的时区计算朔和中气所在的日期,因此偶有月首甚至春节不同
+const (
+	ChineseLunar    = iota // 中国农历,东八区
+	KoreanLunar            // 韩国农历(檀纪),东九区
+	VietnameseLunar        // 越南农历,东七区,卯年生肖为猫
+)
+
+var (
+	// 韩国的公历节日,写法同gregorianFestivalArray
+	koreanGregorianFestivalArray = map[string]string{"1M1D": "*新年", "3M1D": "*三一节", "5M5D": "*儿童节", "5M8D": "父母节",
+		"6M6D": "*显忠日", "7M17D": "制宪节", "8M15D": "*光复节", "10M3D": "*开天节", "10M9D": "*韩文日", "12M25D": "*圣诞节"}
+
+	// 韩国的农历节日,写法同lunarFestivalArray
+	koreanLunarFestivalArray = map[string]string{"1M1D": "*春节", "1M15D": "*正月大望", "4M8D": "*佛诞日", "5M5D": "*端午",
+		"7M7D": "七夕", "8M15D": "*秋夕", "9M9D": "重阳", "12M$": "除夕"}
+
+	// 越南的公历节日,写法同gregorianFestivalArray
+	vietnameseGregorianFestivalArray = map[string]string{"1M1D": "*元旦", "2M3D": "越南共产党成立日", "3M8D": "*国际妇女节",
+		"4M30D": "*南方解放日", "5M1D": "*劳动节", "6M1D": "*儿童节", "9M2D": "*国庆节", "10M20D": "越南妇女节",
+		"11M20D": "*越南教师节", "12M25D": "*圣诞节"}
+
+	// 越南的农历节日,写法同lunarFestivalArray
+	vietnameseLunarFestivalArray = map[string]string{"1M1D": "*春节", "1M15D": "*元宵节", "3M3D": "寒食节", "3M10D": "*雄王祭",
+		"4M15D": "*佛诞节", "5M5D": "*端午节", "7M15D": "*中元节", "8M15D": "*中秋节", "12M23D": "*灶君节", "12M$": "*除夕"}
+
+	// 韩国和越南农历默认使用的节日表
+	koreanFestivalRegistry     = newLunarVariantFestivalRegistry(koreanGregorianFestivalArray, koreanLunarFestivalArray)
+	vietnameseFestivalRegistry = newLunarVariantFestivalRegistry(vietnameseGregorianFestivalArray, vietnameseLunarFestivalArray)
+)
+
+// newLunarVariantFestivalRegistry 以默认节日表为基础,用gregorian和lunar替换其中的公历和农历节日
+//
+// 伊斯兰历等其它历法的节日仍用默认节日表中的
+func newLunarVariantFestivalRegistry(gregorian, lunar map[string]string) *FestivalRegistry {
+	r := NewFestivalRegistry(defaultFestivalRegistry)
+	for k := range gregorianFestivalArray {
+		r.entries[FestivalGregorian] = setFestivalEntry(r.entries[FestivalGregorian], k, festivalEntry{replace: true})
+	}
+	for k := range lunarFestivalArray {
+		r.entries[FestivalLunar] = setFestivalEntry(r.entries[FestivalLunar], k, festivalEntry{replace: true})
+	}
+	for k, v := range gregorian {
+		r.entries[FestivalGregorian] = setFestivalEntry(r.entries[FestivalGregorian], k, festivalEntry{names: v, replace: true})
+	}
+	for k, v := range lunar {
+		r.entries[FestivalLunar] = setFestivalEntry(r.entries[FestivalLunar], k, festivalEntry{names: v, replace: true})
+	}
+	return r
+}
+
+// LunarVariantFestivalRegistry 农历变体默认使用的节日表
+//
+// 未设置节日表的Calendar按CalendarConfig.LunarVariant使用该节日表,中国农历即DefaultFestivalRegistry()。
+// 韩国和越南的节日表以默认节日表为基础,替换了其中的公历和农历节日
+func LunarVariantFestivalRegistry(variant int) *FestivalRegistry {
+	switch variant {
+	case KoreanLunar:
+		return koreanFestivalRegistry
+	case VietnameseLunar:
+		return vietnameseFestivalRegistry
+	}
+	return defaultFestivalRegistry
+}
+
+// (*Calendar) lunarTimeOffsetDays 农历所用时区相对UTC的偏移量(天)
+func (c *Calendar) lunarTimeOffsetDays() float64 {
+	switch c.config.LunarVariant {
+	case KoreanLunar:
+		return 9 / 24.0
+	case VietnameseLunar:
+		return 7 / 24.0
+	}
+	return cChineseTimeOffsetDays
+}
+
+// (*Calendar) animalName 地支索引ebi对应的生肖名称,越南农历的卯年为猫
+func (c *Calendar) animalName(l *Locale, ebi int) string {
+	if ebi == 3 && c.config.LunarVariant == VietnameseLunar && l.Cat != "" {
+		return l.Cat
+	}
+	return l.Animals[ebi]
+}
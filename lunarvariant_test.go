@@ -0,0 +1,88 @@
+package gocalendar
+
+import (
+	"testing"
+)
+
+func TestLunarVariant_NewYear(t *testing.T) {
+	// 春节,朔在不同时区落在不同日期;1985年越南按东七区,冬至所在月不同,春节早一个月
+	tests := []struct {
+		year    int
+		variant int
+		want    string
+	}{
+		{1985, ChineseLunar, "1985-02-20"},
+		{1985, KoreanLunar, "1985-02-20"},
+		{1985, VietnameseLunar, "1985-01-21"},
+		{1997, ChineseLunar, "1997-02-07"},
+		{1997, KoreanLunar, "1997-02-08"},
+		{2007, ChineseLunar, "2007-02-18"},
+		{2007, KoreanLunar, "2007-02-18"},
+		{2007, VietnameseLunar, "2007-02-17"},
+		{2027, ChineseLunar, "2027-02-06"},
+		{2027, KoreanLunar, "2027-02-07"},
+	}
+	for _, tt := range tests {
+		c := NewCalendar(CalendarConfig{TimeZoneName: "UTC", LunarVariant: tt.variant})
+		g, err := c.LunarToGregorian(tt.year, 1, 1, false)
+		ld := c.GregorianToLunar(g.Year(), int(g.Month()), g.Day())
+		if err == nil && g.Format("2006-01-02") == tt.want && ld.Year == tt.year && ld.Month == 1 && ld.Day == 1 {
+			t.Log("passed")
+		} else {
+			t.Error(tt, g, err, ld.Year, ld.Month, ld.Day)
+		}
+	}
+
+	// 1985-01-21在中国农历是1984年腊月初一
+	c := NewCalendar(CalendarConfig{TimeZoneName: "UTC"})
+	if ld := c.GregorianToLunar(1985, 1, 21); ld.Year == 1984 && ld.Month == 12 && ld.Day == 1 {
+		t.Log("passed")
+	} else {
+		t.Error(ld)
+	}
+}
+
+func TestLunarVariant_Calendar(t *testing.T) {
+	// 越南农历卯年生肖为猫,节日用越南的节日表
+	vi := NewCalendar(CalendarConfig{Grid: GridDay, TimeZoneName: "Asia/Ho_Chi_Minh", Lunar: true, LunarVariant: VietnameseLunar, LunarTable: true})
+	items := vi.GenerateWithDate(2023, 1, 22)
+	ld := items[0].LunarDate
+	if ld.AnimalName == "猫" && ld.Festival.Show[0] == "春节" && ld.String() == "2023癸卯(猫)年正月初一 春节" {
+		t.Log("passed")
+	} else {
+		t.Error(ld)
+	}
+	items = vi.GenerateWithDate(2023, 4, 29)
+	if ld := items[0].LunarDate; ld.Month == 3 && ld.Day == 10 && ld.Festival.Show[0] == "雄王祭" {
+		t.Log("passed")
+	} else {
+		t.Error(ld)
+	}
+	items = vi.GenerateWithDate(2023, 4, 30)
+	if fi := items[0].Festival; len(fi.Show) == 1 && fi.Show[0] == "南方解放日" {
+		t.Log("passed")
+	} else {
+		t.Error(fi)
+	}
+
+	// 韩国农历,不查中国农历表
+	ko := NewCalendar(CalendarConfig{Grid: GridDay, TimeZoneName: "Asia/Seoul", Locale: "en", Lunar: true, LunarVariant: KoreanLunar, LunarTable: true})
+	items = ko.GenerateWithDate(1997, 2, 8)
+	if ld := items[0].LunarDate; ld.Month == 1 && ld.Day == 1 && ld.AnimalName == "Ox" && ld.Festival.Show[0] == "春节" {
+		t.Log("passed")
+	} else {
+		t.Error(ld)
+	}
+	items = ko.GenerateWithDate(2021, 9, 21)
+	if ld := items[0].LunarDate; ld.Festival.Show[0] == "秋夕" && len(items[0].Festival.Show) == 0 {
+		t.Log("passed")
+	} else {
+		t.Error(ld, items[0].Festival)
+	}
+
+	if LunarVariantFestivalRegistry(ChineseLunar) == DefaultFestivalRegistry() && LunarVariantFestivalRegistry(KoreanLunar).Base() == DefaultFestivalRegistry() {
+		t.Log("passed")
+	} else {
+		t.Error("LunarVariantFestivalRegistry")
+	}
+}
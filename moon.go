@@ -0,0 +1,294 @@
+package gocalendar
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// 月相
+const (
+	MoonNew            = iota // 新月(朔)
+	MoonWaxingCrescent        // 蛾眉月
+	MoonFirstQuarter          // 上弦月
+	MoonWaxingGibbous         // 盈凸月
+	MoonFull                  // 满月(望)
+	MoonWaningGibbous         // 亏凸月
+	MoonLastQuarter           // 下弦月
+	MoonWaningCrescent        // 残月
+)
+
+// 月相名称,索引为月相
+var moonPhaseNameArray = [8]string{"新月", "蛾眉月", "上弦月", "盈凸月", "满月", "亏凸月", "下弦月", "残月"}
+
+// type MoonPhaseItem struct 月相
+type MoonPhaseItem struct {
+	Index      int        `json:"index"`    // 月相索引,偶数为朔、上弦、望、下弦四个主要月相
+	Name       string     `json:"name"`     // 月相名称
+	Time       *time.Time `json:"time"`     // 主要月相的时刻,其它月相为nil
+	Fraction   float64    `json:"fraction"` // 月面被照亮的比例 0-1
+	PhaseAngle float64    `json:"angle"`    // 月相角(日月对地球的张角,度) 0-180,0为满月,180为新月
+}
+
+// moonPhaseJd 主要月相的儒略日(TT)
+//
+// 算法摘自Jean Meeus《Astronomical Algorithms》第49章 Phases of the Moon,
+// k为以2000年1月6日新月为0的朔望月序数,小数部分0、0.25、0.5、0.75分别对应朔、上弦、望、下弦
+func moonPhaseJd(k float64) float64 {
+	phase := k - math.Floor(k)
+	if phase == 0 {
+		return trueNewMoon(k)
+	}
+
+	nme := newMoonEstimated(k)
+
+	t := julianCentury(nme)
+	t2 := math.Pow(t, 2)
+	t3 := math.Pow(t, 3)
+	t4 := math.Pow(t, 4)
+
+	// 平月相时刻
+	mp := nme + 0.0001337*t2 - 0.00000015*t3 + 0.00000000073*t4
+
+	// 太阳平近点角
+	m := 2.5534 + 29.10535669*k - 0.0000218*t2 - 0.00000011*t3
+
+	// 月球平近点角
+	ms := 201.5643 + 385.81693528*k + 0.0107438*t2 + 0.00001239*t3 - 0.000000058*t4
+
+	// 月球纬度参数
+	f := 160.7108 + 390.67050274*k - 0.0016341*t2 - 0.00000227*t3 + 0.000000011*t4
+
+	// 月球轨道升交点经度
+	omega := 124.7746 - 1.5637558*k + 0.0020691*t2 + 0.00000215*t3
+
+	e := 1 - 0.002516*t - 0.0000074*t2
+
+	pi180 := math.Pi / 180
+	sin := func(x float64) float64 { return math.Sin(pi180 * x) }
+	cos := func(x float64) float64 { return math.Cos(pi180 * x) }
+
+	var apt1 float64
+	if phase == 0.5 { // 望
+		apt1 = -0.40614 * sin(ms)
+		apt1 += 0.17302 * e * sin(m)
+		apt1 += 0.01614 * sin(2*ms)
+		apt1 += 0.01043 * sin(2*f)
+		apt1 += 0.00734 * e * sin(ms-m)
+		apt1 -= 0.00515 * e * sin(ms+m)
+		apt1 += 0.00209 * e * e * sin(2*m)
+		apt1 -= 0.00111 * sin(ms-2*f)
+		apt1 -= 0.00057 * sin(ms+2*f)
+		apt1 += 0.00056 * e * sin(2*ms+m)
+		apt1 -= 0.00042 * sin(3*ms)
+		apt1 += 0.00042 * e * sin(m+2*f)
+		apt1 += 0.00038 * e * sin(m-2*f)
+		apt1 -= 0.00024 * e * sin(2*ms-m)
+		apt1 -= 0.00017 * sin(omega)
+		apt1 -= 0.00007 * sin(ms+2*m)
+		apt1 += 0.00004 * sin(2*ms-2*f)
+		apt1 += 0.00004 * sin(3*m)
+		apt1 += 0.00003 * sin(ms+m-2*f)
+		apt1 += 0.00003 * sin(2*ms+2*f)
+		apt1 -= 0.00003 * sin(ms+m+2*f)
+		apt1 += 0.00003 * sin(ms-m+2*f)
+		apt1 -= 0.00002 * sin(ms-m-2*f)
+		apt1 -= 0.00002 * sin(3*ms+m)
+		apt1 += 0.00002 * sin(4*ms)
+	} else { // 上弦、下弦
+		apt1 = -0.62801 * sin(ms)
+		apt1 += 0.17172 * e * sin(m)
+		apt1 -= 0.01183 * e * sin(ms+m)
+		apt1 += 0.00862 * sin(2*ms)
+		apt1 += 0.00804 * sin(2*f)
+		apt1 += 0.00454 * e * sin(ms-m)
+		apt1 += 0.00204 * e * e * sin(2*m)
+		apt1 -= 0.00180 * sin(ms-2*f)
+		apt1 -= 0.00070 * sin(ms+2*f)
+		apt1 -= 0.00040 * sin(3*ms)
+		apt1 -= 0.00034 * e * sin(2*ms-m)
+		apt1 += 0.00032 * e * sin(m+2*f)
+		apt1 += 0.00032 * e * sin(m-2*f)
+		apt1 -= 0.00028 * e * e * sin(ms+2*m)
+		apt1 += 0.00027 * e * sin(2*ms+m)
+		apt1 -= 0.00017 * sin(omega)
+		apt1 -= 0.00005 * sin(ms-m-2*f)
+		apt1 += 0.00004 * sin(2*ms+2*f)
+		apt1 -= 0.00004 * sin(ms+m+2*f)
+		apt1 += 0.00004 * sin(ms-2*m)
+		apt1 += 0.00003 * sin(ms+m-2*f)
+		apt1 += 0.00003 * sin(3*m)
+		apt1 += 0.00002 * sin(2*ms-2*f)
+		apt1 += 0.00002 * sin(ms-m+2*f)
+		apt1 -= 0.00002 * sin(3*ms+m)
+
+		w := 0.00306 - 0.00038*e*cos(m) + 0.00026*cos(ms) - 0.00002*cos(ms-m) + 0.00002*cos(ms+m) + 0.00002*cos(2*f)
+		if phase == 0.25 {
+			apt1 += w
+		} else {
+			apt1 -= w
+		}
+	}
+
+	// 各月相共用的修正项
+	apt2 := 0.000325 * sin(299.77+0.107408*k-0.009173*t2)
+	apt2 += 0.000165 * sin(251.88+0.016321*k)
+	apt2 += 0.000164 * sin(251.83+26.651886*k)
+	apt2 += 0.000126 * sin(349.42+36.412478*k)
+	apt2 += 0.00011 * sin(84.66+18.206239*k)
+	apt2 += 0.000062 * sin(141.74+53.303771*k)
+	apt2 += 0.00006 * sin(207.14+2.453732*k)
+	apt2 += 0.000056 * sin(154.84+7.30686*k)
+	apt2 += 0.000047 * sin(34.52+27.261239*k)
+	apt2 += 0.000042 * sin(207.19+0.121824*k)
+	apt2 += 0.00004 * sin(291.34+1.844379*k)
+	apt2 += 0.000037 * sin(161.72+24.198154*k)
+	apt2 += 0.000035 * sin(239.56+25.513099*k)
+	apt2 += 0.000023 * sin(331.55+3.592518*k)
+
+	return Round(mp+apt1+apt2, 10)
+}
+
+// moonPhaseUtJd 主要月相的儒略日(UT)
+//
+// 超出Delta T的有效范围时按外推的Delta T计算
+func moonPhaseUtJd(k float64) float64 {
+	jd := moonPhaseJd(k)
+	tm := JdToTimeMap(jd)
+	dtd, _ := deltaTDays(float64(tm["year"]), float64(tm["month"]))
+	return Round(jd-dtd, 10)
+}
+
+// type moonPhaseJdItem struct 主要月相及其儒略日(UT)
+type moonPhaseJdItem struct {
+	index int
+	jd    float64
+}
+
+// moonPhasesBetween startJd至endJd(UT,不包括endJd)之间的主要月相,按时间先后排列
+func moonPhasesBetween(startJd, endJd float64) []moonPhaseJdItem {
+	var mps []moonPhaseJdItem
+
+	// 均值与实际月相最多相差约0.6天,前后各多取一个朔望月
+	for k := referenceLunarMonthNum(startJd) - 1; newMoonEstimated(k) < endJd+cMSM; k++ {
+		for i := 0; i < 4; i++ {
+			jd := moonPhaseUtJd(k + float64(i)/4)
+			if jd >= startJd && jd < endJd {
+				mps = append(mps, moonPhaseJdItem{index: i * 2, jd: jd})
+			}
+		}
+	}
+
+	sort.Slice(mps, func(i, j int) bool { return mps[i].jd < mps[j].jd })
+
+	return mps
+}
+
+// MoonPhases start至end之间(包括start和end)的朔、上弦、望、下弦时刻
+//
+// 返回的时间使用start的时区,按时间先后排列
+func MoonPhases(start, end time.Time) []*MoonPhaseItem {
+	loc := start.Location()
+	if start.After(end) {
+		start, end = end, start
+	}
+
+	var items []*MoonPhaseItem
+	for _, mp := range moonPhasesBetween(timeToJd(start), timeToJd(end)+1.0/86400) {
+		mt := JdToTime(mp.jd, loc)
+		fraction, angle := moonIlluminationJd(mp.jd)
+		items = append(items, &MoonPhaseItem{
+			Index:      mp.index,
+			Name:       moonPhaseNameArray[mp.index],
+			Time:       &mt,
+			Fraction:   fraction,
+			PhaseAngle: angle,
+		})
+	}
+
+	return items
+}
+
+// MoonIllumination 时刻t月面被照亮的比例(0-1)和月相角(度)
+//
+// 月相角是太阳和地球对月球中心的张角,0为满月,180为新月
+func MoonIllumination(t time.Time) (fraction, phaseAngle float64) {
+	return moonIlluminationJd(timeToJd(t))
+}
+
+// moonIlluminationJd 儒略日(UT)jd时月面被照亮的比例和月相角
+//
+// 算法摘自Jean Meeus《Astronomical Algorithms》第48章 Illuminated Fraction of the Moon's Disk 的简化公式
+func moonIlluminationJd(jd float64) (fraction, phaseAngle float64) {
+	tm := JdToTimeMap(jd)
+	dtd, _ := deltaTDays(float64(tm["year"]), float64(tm["month"])) // 超出有效范围时为外推值
+	t := julianCentury(jd + dtd)
+	t2 := math.Pow(t, 2)
+	t3 := math.Pow(t, 3)
+	t4 := math.Pow(t, 4)
+
+	// 月球平距角
+	d := 297.8501921 + 445267.1114034*t - 0.0018819*t2 + t3/545868 - t4/113065000
+
+	// 太阳平近点角
+	m := 357.5291092 + 35999.0502909*t - 0.0001536*t2 + t3/24490000
+
+	// 月球平近点角
+	ms := 134.9633964 + 477198.8675055*t + 0.0087414*t2 + t3/69699 - t4/14712000
+
+	pi180 := math.Pi / 180
+	i := 180 - d - 6.289*math.Sin(pi180*ms) + 2.1*math.Sin(pi180*m) - 1.274*math.Sin(pi180*(2*d-ms)) -
+		0.658*math.Sin(pi180*2*d) - 0.214*math.Sin(pi180*2*ms) - 0.11*math.Sin(pi180*d)
+
+	// 化为0-180度
+	i = math.Mod(i, 360)
+	if i < 0 {
+		i += 360
+	}
+	if i > 180 {
+		i = 360 - i
+	}
+
+	return Round((1+math.Cos(pi180*i))/2, 4), Round(i, 4)
+}
+
+// (*Calendar) moonPhase t所在日期的月相
+//
+// 该日有朔、上弦、望、下弦时返回该主要月相及其时刻,否则返回两个主要月相之间的月相和当日正午的月面照亮比例
+func (c *Calendar) moonPhase(t time.Time) *MoonPhaseItem {
+	l := c.locale()
+	year, month, day := t.In(c.loc).Date()
+	dayStart := time.Date(year, month, day, 0, 0, 0, 0, c.loc)
+	startJd := timeToJd(dayStart)
+	endJd := timeToJd(dayStart.AddDate(0, 0, 1))
+
+	// 往前多取8天,以找到该日之前的最后一个主要月相
+	mps := moonPhasesBetween(startJd-8, endJd)
+
+	for _, mp := range mps {
+		if mp.jd >= startJd {
+			mt := JdToTime(mp.jd, c.loc)
+			fraction, angle := moonIlluminationJd(mp.jd)
+			return &MoonPhaseItem{
+				Index:      mp.index,
+				Name:       l.MoonPhases[mp.index],
+				Time:       &mt,
+				Fraction:   fraction,
+				PhaseAngle: angle,
+			}
+		}
+	}
+
+	index := MoonNew
+	if len(mps) > 0 {
+		index = (mps[len(mps)-1].index + 1) % 8
+	}
+
+	fraction, angle := moonIlluminationJd(timeToJd(dayStart.Add(12 * time.Hour)))
+	return &MoonPhaseItem{
+		Index:      index,
+		Name:       l.MoonPhases[index],
+		Fraction:   fraction,
+		PhaseAngle: angle,
+	}
+}
@@ -0,0 +1,71 @@
+package gocalendar
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMoonPhases(t *testing.T) {
+	start := time.Date(2021, 5, 19, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, 6, 10, 23, 59, 59, 0, time.UTC)
+
+	want := []struct {
+		index int
+		time  time.Time
+	}{
+		{MoonFirstQuarter, time.Date(2021, 5, 19, 19, 13, 0, 0, time.UTC)},
+		{MoonFull, time.Date(2021, 5, 26, 11, 14, 0, 0, time.UTC)},
+		{MoonLastQuarter, time.Date(2021, 6, 2, 7, 24, 0, 0, time.UTC)},
+		{MoonNew, time.Date(2021, 6, 10, 10, 53, 0, 0, time.UTC)},
+	}
+
+	mps := MoonPhases(start, end)
+	if len(mps) != len(want) {
+		t.Fatal(mps)
+	}
+	for i, v := range want {
+		if mps[i].Index != v.index || math.Abs(mps[i].Time.Sub(v.time).Minutes()) > 2 {
+			t.Error(mps[i].Name, mps[i].Time, v.time)
+		}
+	}
+}
+
+func TestMoonIllumination(t *testing.T) {
+	f, a := MoonIllumination(time.Date(2021, 5, 26, 11, 14, 0, 0, time.UTC))
+	if f > 0.99 && a < 10 {
+		t.Log("passed")
+	} else {
+		t.Error(f, a)
+	}
+
+	f, _ = MoonIllumination(time.Date(2021, 5, 19, 19, 13, 0, 0, time.UTC))
+	if math.Abs(f-0.5) < 0.02 {
+		t.Log("passed")
+	} else {
+		t.Error(f)
+	}
+}
+
+func TestCalendar_MoonPhaseItem(t *testing.T) {
+	c := NewCalendar(CalendarConfig{Grid: GridWeek, TimeZoneName: "Asia/Shanghai", MoonPhase: true})
+	items := c.GenerateWithDate(2021, 5, 26)
+
+	for _, item := range items {
+		mp := item.MoonPhase
+		switch item.Time.Day() {
+		case 26:
+			if mp.Index != MoonFull || mp.Time == nil || mp.Time.Hour() != 19 {
+				t.Error(mp)
+			}
+		case 27:
+			if mp.Index != MoonWaningGibbous || mp.Time != nil {
+				t.Error(mp)
+			}
+		case 25:
+			if mp.Index != MoonWaxingGibbous {
+				t.Error(mp)
+			}
+		}
+	}
+}
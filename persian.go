@@ -0,0 +1,249 @@
+package gocalendar
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 波斯历(伊朗历,太阳希吉来历)的算法
+const (
+	PersianAstronomical = iota // 春分在德黑兰真正午之前则当日为岁首,否则次日为岁首,与伊朗官方历法相同
+	PersianArithmetic          // 算术历法(33年8闰),与天文算法在个别年份相差一天
+)
+
+const (
+	// 按33年8闰反推的元年法尔瓦丁月1日的儒略日数
+	cPersianEpochJdn = 1948320
+
+	// 德黑兰的经度(度),及伊朗标准时间UTC+3:30(日)
+	cTehranLongitude = 51.42
+	cTehranOffset    = 3.5 / 24
+)
+
+var (
+	// 波斯历月份名称
+	persianMonthNameArray = [12]string{"法尔瓦丁月", "奥尔迪别赫什特月", "霍尔达德月", "提尔月", "莫尔达德月", "沙赫里瓦尔月",
+		"梅赫尔月", "阿班月", "阿扎尔月", "达伊月", "巴赫曼月", "埃斯凡德月"}
+
+	// 波斯历节日,索引写法同公历节日的"月M日D",如1M1D表示法尔瓦丁月1日,节日名称前加"*"号表示重要且在日历表上显示
+	persianFestivalArray = map[string]string{"1M1D": "*诺鲁孜节", "1M13D": "*自然日", "4M13D": "提尔甘节", "7M16D": "梅赫尔甘节",
+		"9M30D": "*雅尔达之夜", "11M10D": "萨德节"}
+)
+
+// type PersianDate struct 波斯历日期
+type PersianDate struct {
+	Year       int           `json:"year"`      // 年
+	Month      int           `json:"month"`     // 月
+	Day        int           `json:"day"`       // 日
+	MonthName  string        `json:"monthName"` // 月份名称
+	MonthDays  int           `json:"monthDays"` // 该月天数,前6个月31天,后5个月30天,埃斯凡德月29或30天
+	IsLeapYear bool          `json:"leap"`      // 该年是否闰年(366天)
+	Nowruz     *time.Time    `json:"nowruz"`    // 诺鲁孜(春分)的时刻,只在法尔瓦丁月1日有值
+	Festival   *FestivalItem `json:"festival"`  // 波斯历节日
+
+	locale *Locale // 显示用的语言
+}
+
+// (PersianDate) String 波斯历日期显示
+func (pd PersianDate) String() string {
+	return fmt.Sprintf(pd.locale.orDefault().PersianDateFormat, pd.Year, pd.MonthName, pd.Day)
+}
+
+// GregorianToPersianArithmetic 公历转波斯历,算术历法
+//
+// 与农历相同,1582年10月15日之前的日期按儒略历
+func GregorianToPersianArithmetic(year, month, day int) (persianYear, persianMonth, persianDay int) {
+	return persianFromJdn(jdnOfDate(year, month, day), persianArithmeticNewYear)
+}
+
+// PersianArithmeticToGregorian 波斯历转公历,算术历法
+//
+// 月份或日期不合法时返回ErrInvalidDate,日期大于该月天数时返回ErrDayOutOfMonth
+func PersianArithmeticToGregorian(persianYear, persianMonth, persianDay int) (year, month, day int, err error) {
+	if persianYear < 1 || persianMonth < 1 || persianMonth > 12 || persianDay < 1 || persianDay > 31 {
+		return 0, 0, 0, ErrInvalidDate
+	}
+	if persianDay > persianMonthDays(persianYear, persianMonth, persianArithmeticNewYear) {
+		return 0, 0, 0, ErrDayOutOfMonth
+	}
+
+	tm := JdToTimeMap(float64(persianJdn(persianYear, persianMonth, persianDay, persianArithmeticNewYear)))
+	return tm["year"], tm["month"], tm["day"], nil
+}
+
+// persianArithmeticNewYear 算术历法该年法尔瓦丁月1日的儒略日数
+func persianArithmeticNewYear(persianYear int) int {
+	// 33年8闰,(25*年+11)除以33的余数小于8为闰年
+	return cPersianEpochJdn + 365*(persianYear-1) + floorDiv(8*persianYear+21, 33)
+}
+
+// persianEquinoxJd 波斯历该年岁首附近的春分时刻(UT儒略日),与SolarTerms中的春分相同
+//
+// 超出Delta T的有效范围时按外推的Delta T计算
+func persianEquinoxJd(persianYear int) float64 {
+	jqs, _ := adjustedSolarTermsJd(float64(persianYear+621), 0, 0)
+	return jqs[0]
+}
+
+// persianAstronomicalNewYear 天文算法该年法尔瓦丁月1日的儒略日数
+//
+// 按德黑兰当地日期,春分在该日真太阳时正午之前则当日为岁首,否则次日为岁首
+func persianAstronomicalNewYear(persianYear int) int {
+	eq := persianEquinoxJd(persianYear)
+	jdn := int(math.Floor(eq + cTehranOffset + 0.5))
+	if eq > solarNoonJd(float64(jdn)-cTehranOffset, cTehranLongitude) {
+		jdn++
+	}
+	return jdn
+}
+
+// persianJdn 波斯历日期的儒略日数,newYear为该算法的岁首
+func persianJdn(persianYear, persianMonth, persianDay int, newYear func(int) int) int {
+	if persianMonth <= 6 {
+		return newYear(persianYear) + 31*(persianMonth-1) + persianDay - 1
+	}
+	return newYear(persianYear) + 186 + 30*(persianMonth-7) + persianDay - 1
+}
+
+// persianFromJdn 儒略日数对应的波斯历日期,newYear为该算法的岁首
+func persianFromJdn(jdn int, newYear func(int) int) (persianYear, persianMonth, persianDay int) {
+	// 平均年长约365.2422天,估算后前后调整
+	persianYear = floorDiv((jdn-cPersianEpochJdn)*10000, 3652422) + 1
+	for newYear(persianYear) > jdn {
+		persianYear--
+	}
+	for newYear(persianYear+1) <= jdn {
+		persianYear++
+	}
+
+	doy := jdn - newYear(persianYear)
+	if doy < 186 {
+		return persianYear, doy/31 + 1, doy%31 + 1
+	}
+	doy -= 186
+	return persianYear, doy/30 + 7, doy%30 + 1
+}
+
+// persianMonthDays 波斯历某月的天数,newYear为该算法的岁首
+func persianMonthDays(persianYear, persianMonth int, newYear func(int) int) int {
+	switch {
+	case persianMonth <= 6:
+		return 31
+	case persianMonth < 12:
+		return 30
+	}
+	return newYear(persianYear+1) - newYear(persianYear) - 336
+}
+
+// (*Calendar) GregorianToPersian 公历转波斯历,算法由CalendarConfig.PersianMethod指定
+func (c *Calendar) GregorianToPersian(year, month, day int) PersianDate {
+	py, pm, pd := persianFromJdn(jdnOfDate(year, month, day), c.persianNewYear)
+	l := c.locale()
+	fi := c.persianFestival(py, pm, pd)
+
+	d := PersianDate{
+		Year:       py,
+		Month:      pm,
+		Day:        pd,
+		MonthName:  l.PersianMonths[pm-1],
+		MonthDays:  persianMonthDays(py, pm, c.persianNewYear),
+		IsLeapYear: c.persianNewYear(py+1)-c.persianNewYear(py) == 366,
+		Festival:   &fi,
+		locale:     l,
+	}
+
+	if pm == 1 && pd == 1 {
+		if t, err := c.Nowruz(py); err == nil {
+			d.Nowruz = &t
+		}
+	}
+
+	return d
+}
+
+// (*Calendar) PersianToGregorian 波斯历转公历,算法由CalendarConfig.PersianMethod指定,返回日历时区该日0时
+//
+// 月份或日期不合法时返回ErrInvalidDate,日期大于该月天数时返回ErrDayOutOfMonth,超出计算范围时返回ErrOutOfRange
+func (c *Calendar) PersianToGregorian(persianYear, persianMonth, persianDay int) (time.Time, error) {
+	var err error
+	switch {
+	case persianYear < 1 || persianMonth < 1 || persianMonth > 12 || persianDay < 1 || persianDay > 31:
+		err = ErrInvalidDate
+	case checkYear(persianYear+621) != nil:
+		err = checkYear(persianYear + 621)
+	case persianDay > persianMonthDays(persianYear, persianMonth, c.persianNewYear):
+		err = ErrDayOutOfMonth
+	}
+	if err != nil {
+		return time.Time{}, &DateError{Op: "PersianToGregorian", Year: persianYear, Month: persianMonth, Day: persianDay, Err: err}
+	}
+
+	return lunarTableTime(persianJdn(persianYear, persianMonth, persianDay, c.persianNewYear), c.loc), nil
+}
+
+// (*Calendar) Nowruz 波斯历该年的诺鲁孜(春分)时刻,在日历时区
+//
+// 超出计算范围时返回ErrOutOfRange
+func (c *Calendar) Nowruz(persianYear int) (time.Time, error) {
+	if err := checkYear(persianYear + 621); err != nil {
+		return time.Time{}, &DateError{Op: "Nowruz", Year: persianYear, Err: err}
+	}
+
+	return JdToTime(persianEquinoxJd(persianYear), c.loc), nil
+}
+
+// (*Calendar) persianNewYear 该年法尔瓦丁月1日的儒略日数,算法由CalendarConfig.PersianMethod指定
+func (c *Calendar) persianNewYear(persianYear int) int {
+	if c.config.PersianMethod == PersianArithmetic {
+		return persianArithmeticNewYear(persianYear)
+	}
+
+	// 以德黑兰为准,与时区和地理位置无关
+	k := cacheKey{kind: cachePersianNewYear, year: persianYear}
+	ac := c.astroCache()
+	if v, ok := ac.get(k); ok {
+		return v.(int)
+	}
+
+	jdn := persianAstronomicalNewYear(persianYear)
+	ac.set(k, jdn)
+
+	return jdn
+}
+
+// (*Calendar) persianFestival 波斯历节日
+func (c *Calendar) persianFestival(persianYear, persianMonth, persianDay int) FestivalItem {
+	fr := c.festivalRegistry()
+
+	// 节日表修改后清除已缓存的节日
+	c.tempData.pFD.sync(fr.version())
+
+	fds := c.tempData.pFD.getData(persianYear)
+
+	if len(fds) == 0 {
+		for k, v := range fr.Festivals(FestivalPersian) {
+			re := gregorianFestivalDayRegexp.FindStringSubmatch(k)
+			if len(re) != 3 {
+				continue // 索引格式不正确
+			}
+			month, _ := strconv.Atoi(re[1])
+			day, _ := strconv.Atoi(re[2])
+			if month < 1 || month > 12 || day < 1 || day > 31 {
+				continue // 月份或日期不正确
+			}
+
+			trueK := strconv.Itoa(month) + "M" + strconv.Itoa(day) + "D"
+			fds[trueK] = append(fds[trueK], strings.Split(v, ",")...)
+		}
+
+		c.tempData.pFD.setData(persianYear, fds)
+	}
+
+	festivalIndex := strconv.Itoa(persianMonth) + "M" + strconv.Itoa(persianDay) + "D"
+
+	// 该日的节日
+	return festivalItemFromNames(fds[festivalIndex])
+}
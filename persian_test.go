@@ -0,0 +1,136 @@
+package gocalendar
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGregorianToPersianArithmetic(t *testing.T) {
+	tests := []struct {
+		y, m, d    int
+		py, pm, pd int
+	}{
+		{2020, 3, 19, 1398, 12, 29},
+		{2020, 3, 20, 1399, 1, 1},
+		{2021, 3, 20, 1399, 12, 30},
+		{2021, 3, 21, 1400, 1, 1},
+		{2021, 9, 22, 1400, 6, 31},
+		{2021, 9, 23, 1400, 7, 1},
+		{2023, 12, 21, 1402, 9, 30},
+	}
+	for _, tt := range tests {
+		py, pm, pd := GregorianToPersianArithmetic(tt.y, tt.m, tt.d)
+		y, m, d, err := PersianArithmeticToGregorian(tt.py, tt.pm, tt.pd)
+		if py == tt.py && pm == tt.pm && pd == tt.pd && err == nil && y == tt.y && m == tt.m && d == tt.d {
+			t.Log("passed")
+		} else {
+			t.Error(tt, py, pm, pd, y, m, d, err)
+		}
+	}
+
+	// 1399年为闰年,埃斯凡德月30天;1400年29天
+	if _, _, _, err := PersianArithmeticToGregorian(1400, 12, 30); !errors.Is(err, ErrDayOutOfMonth) {
+		t.Error(err)
+	}
+	if _, _, _, err := PersianArithmeticToGregorian(1400, 7, 31); !errors.Is(err, ErrDayOutOfMonth) {
+		t.Error(err)
+	}
+	if _, _, _, err := PersianArithmeticToGregorian(1400, 13, 1); !errors.Is(err, ErrInvalidDate) {
+		t.Error(err)
+	}
+}
+
+func TestCalendar_GregorianToPersian(t *testing.T) {
+	astro := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Tehran"})
+	arith := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Tehran", PersianMethod: PersianArithmetic})
+
+	// 春分在德黑兰正午之前当日为岁首,之后则次日为岁首;2023年春分在德黑兰已是3月21日凌晨
+	tests := []struct {
+		y, m, d int
+		py      int
+	}{
+		{2020, 3, 20, 1399},
+		{2021, 3, 21, 1400},
+		{2022, 3, 21, 1401},
+		{2023, 3, 21, 1402},
+		{2024, 3, 20, 1403},
+		{2025, 3, 21, 1404},
+	}
+	for _, tt := range tests {
+		pd := astro.GregorianToPersian(tt.y, tt.m, tt.d)
+		prev := astro.GregorianToPersian(tt.y, tt.m, tt.d-1)
+		if pd.Year == tt.py && pd.Month == 1 && pd.Day == 1 && prev.Year == tt.py-1 && prev.Month == 12 && prev.Day == prev.MonthDays {
+			t.Log("passed")
+		} else {
+			t.Error(tt, pd, prev)
+		}
+	}
+
+	// 逐日连续,与波斯历转公历一致
+	for _, c := range []*Calendar{astro, arith} {
+		prev := c.GregorianToPersian(2019, 12, 31)
+		for d := time.Date(2020, 1, 1, 0, 0, 0, 0, c.loc); d.Year() < 2026; d = d.AddDate(0, 0, 1) {
+			pd := c.GregorianToPersian(d.Year(), int(d.Month()), d.Day())
+			next := prev.Day+1 == pd.Day && prev.Month == pd.Month
+			newMonth := pd.Day == 1 && prev.Day == prev.MonthDays && (pd.Month == prev.Month%12+1)
+			if !next && !newMonth {
+				t.Fatalf("%s: %v after %v", d.Format("2006-01-02"), pd, prev)
+			}
+
+			// 伊朗2022年以前有夏令时,在0时开始,按日期比较
+			g, err := c.PersianToGregorian(pd.Year, pd.Month, pd.Day)
+			if err != nil || g.Format("2006-01-02") != d.Format("2006-01-02") {
+				t.Fatalf("%v -> %s, want %s (%v)", pd, g, d, err)
+			}
+			prev = pd
+		}
+	}
+	t.Log("passed")
+
+	if _, err := astro.PersianToGregorian(1400, 12, 30); !errors.Is(err, ErrDayOutOfMonth) {
+		t.Error(err)
+	}
+	if _, err := astro.PersianToGregorian(3000, 1, 1); !errors.Is(err, ErrOutOfRange) {
+		t.Error(err)
+	}
+}
+
+func TestCalendar_PersianItem(t *testing.T) {
+	c := NewCalendar(CalendarConfig{Grid: GridDay, TimeZoneName: "Asia/Tehran", Locale: "en", Persian: true})
+
+	// 诺鲁孜为2021年3月20日13:07:28(德黑兰时间)的春分时刻
+	items := c.GenerateWithDate(2021, 3, 21)
+	pd := items[0].PersianDate
+	if pd != nil && pd.String() == "1 Farvardin 1400 AP" && pd.Festival.Show[0] == "诺鲁孜节" &&
+		pd.Nowruz != nil && pd.Nowruz.Format("2006-01-02 15:04") == "2021-03-20 13:07" {
+		t.Log("passed")
+	} else {
+		t.Error(pd)
+	}
+
+	items = c.GenerateWithDate(2021, 12, 21)
+	if pd := items[0].PersianDate; pd.Month == 9 && pd.Day == 30 && pd.Nowruz == nil && pd.Festival.Show[0] == "雅尔达之夜" {
+		t.Log("passed")
+	} else {
+		t.Error(pd)
+	}
+
+	st := c.SolarTerms(2021)[6]
+	if nowruz, err := c.Nowruz(1400); err == nil && st.Index == 0 && nowruz.Equal(*st.Time) {
+		t.Log("passed")
+	} else {
+		t.Error(nowruz, st, err)
+	}
+}
+
+func TestRegisterLocale_PersianDateFormat(t *testing.T) {
+	l := *LookupLocale("en")
+	l.Name = "en-PE"
+	l.PersianDateFormat = ""
+	if err := RegisterLocale(&l); err != nil && LookupLocale("en-PE") == nil {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,92 @@
+package gocalendar
+
+import (
+	"context"
+	"time"
+)
+
+// type RangeIterator struct 逐日生成日历单元的迭代器,由(*Calendar) Range返回
+//
+// 用法同bufio.Scanner:
+//
+//	it := c.Range(ctx, start, end)
+//	for it.Next() {
+//		item := it.Item()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type RangeIterator struct {
+	c    *Calendar
+	ctx  context.Context
+	next time.Time // 下一个要生成的日期
+	last time.Time // 最后一日
+	year int       // 已预先计算节气的年份
+	item *CalendarItem
+	err  error
+}
+
+// (*Calendar) Range [start, end]内每一日的日历单元,start与end只取日期,顺序可以颠倒
+//
+// 每次调用Next时才计算一日,同一年的节气、节日等共用缓存。
+// ctx取消后Next返回false,Err返回ctx.Err();日期超出计算范围时Err返回ErrOutOfRange。
+// 各单元的IsAccidental都为0,Calendar.Items不变。
+func (c *Calendar) Range(ctx context.Context, start, end time.Time) *RangeIterator {
+	start, end = start.In(c.loc), end.In(c.loc)
+	if start.After(end) {
+		start, end = end, start
+	}
+	sy, sm, sd := start.Date()
+	ey, em, ed := end.Date()
+
+	return &RangeIterator{
+		c:    c,
+		ctx:  ctx,
+		next: time.Date(sy, sm, sd, 0, 0, 0, 0, c.loc),
+		last: time.Date(ey, em, ed, 0, 0, 0, 0, c.loc),
+		year: sy - 1,
+	}
+}
+
+// (*RangeIterator) Next 计算下一日,没有下一日或出错时返回false
+func (it *RangeIterator) Next() bool {
+	it.item = nil
+	if it.err != nil || it.next.After(it.last) {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	t := it.next
+	year, month, day := t.Date()
+	if err := checkYear(year); err != nil {
+		it.err = &DateError{Op: "Range", Year: year, Month: int(month), Day: day, Err: err}
+		return false
+	}
+
+	// 进入新的一年时先算好节气,避免createItem中并发重复计算
+	if year != it.year {
+		if it.c.config.SolarTerms {
+			for y := year - 1; y <= year+1; y++ {
+				it.c.solarTerms(y)
+			}
+		}
+		it.year = year
+	}
+
+	it.item = it.c.createItem(t, year, int(month))
+	it.next = t.AddDate(0, 0, 1)
+
+	return true
+}
+
+// (*RangeIterator) Item Next返回true后的日历单元
+func (it *RangeIterator) Item() *CalendarItem {
+	return it.item
+}
+
+// (*RangeIterator) Err 迭代中的错误,正常结束时为nil
+func (it *RangeIterator) Err() error {
+	return it.err
+}
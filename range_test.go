@@ -0,0 +1,78 @@
+package gocalendar
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCalendar_Range(t *testing.T) {
+	c := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai", SolarTerms: true, Lunar: true, HeavenlyEarthly: true})
+	start := time.Date(2021, 12, 20, 15, 30, 0, 0, c.loc)
+	end := time.Date(2022, 3, 19, 0, 0, 0, 0, c.loc)
+
+	var items []*CalendarItem
+	it := c.Range(context.Background(), end, start)
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 90天,逐日连续,跨年
+	if len(items) != 90 {
+		t.Fatalf("Range len = %d, want 90", len(items))
+	}
+	for i, item := range items {
+		want := time.Date(2021, 12, 20+i, 0, 0, 0, 0, c.loc)
+		if !item.Time.Equal(want) || item.IsAccidental != 0 || item.LunarDate == nil || item.GZ == nil {
+			t.Errorf("%d: %v", i, item)
+		}
+	}
+
+	// 2022-02-01 春节, 2021-12-21 冬至
+	if ld := items[43].LunarDate; ld.Year != 2022 || ld.Month != 1 || ld.Day != 1 {
+		t.Error(items[43].Time, ld)
+	} else if st := items[1].SolarTerm; st == nil || st.Name != "冬至" {
+		t.Error(items[1].Time, st)
+	} else {
+		t.Log("passed")
+	}
+
+	if it.Next() || it.Item() != nil {
+		t.Error("Next after end")
+	}
+}
+
+func TestCalendar_RangeCancel(t *testing.T) {
+	c := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai", Lunar: true})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	n := 0
+	it := c.Range(ctx, time.Date(2021, 1, 1, 0, 0, 0, 0, c.loc), time.Date(2021, 12, 31, 0, 0, 0, 0, c.loc))
+	for it.Next() {
+		n++
+		if n == 10 {
+			cancel()
+		}
+	}
+	if n == 10 && errors.Is(it.Err(), context.Canceled) {
+		t.Log("passed")
+	} else {
+		t.Error(n, it.Err())
+	}
+
+	it = c.Range(context.Background(), time.Date(MaxYear, 12, 30, 0, 0, 0, 0, c.loc), time.Date(MaxYear+1, 1, 2, 0, 0, 0, 0, c.loc))
+	n = 0
+	for it.Next() {
+		n++
+	}
+	if n == 2 && errors.Is(it.Err(), ErrOutOfRange) {
+		t.Log("passed")
+	} else {
+		t.Error(n, it.Err())
+	}
+}
@@ -0,0 +1,140 @@
+package gocalendar
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// LeapMonthPolicy 以闰月日期为准的周年事件,在该月没有闰月的年份的处理方式
+type LeapMonthPolicy int
+
+const (
+	LeapMonthFallback LeapMonthPolicy = iota // 使用当年的同名平月
+	LeapMonthSkip                            // 跳过该年
+)
+
+// MissingDayPolicy 农历日期为三十而当月只有29天时的处理方式
+type MissingDayPolicy int
+
+const (
+	MissingDayLastDay   MissingDayPolicy = iota // 使用当月的最后一天(二十九)
+	MissingDaySkip                              // 跳过该年
+	MissingDayNextMonth                         // 顺延到下个月的初一
+)
+
+// type LunarRecurrence struct 以农历日期为准每年重复的事件,如农历生日、忌日
+type LunarRecurrence struct {
+	Month      int              // 农历月份 1-12
+	Day        int              // 农历日 1-30, 0表示该月的最后一天
+	Leap       bool             // 是否以闰月为准
+	LeapPolicy LeapMonthPolicy  // 没有该闰月的年份的处理方式
+	DayPolicy  MissingDayPolicy // 当月没有该日时的处理方式
+}
+
+// ParseLunarRecurrence 用农历节日索引格式解析周年事件
+//
+// 如"8M15D"表示每年八月十五,"4@M14D"表示闰四月十四(没有闰四月的年份用四月十四),
+// "12M$"表示每年腊月的最后一天
+func ParseLunarRecurrence(key string) (LunarRecurrence, error) {
+	re := lunarFestivalRegexp.FindStringSubmatch(key)
+	if len(re) != 5 || (re[3] == "") == (re[4] == "") {
+		return LunarRecurrence{}, errors.New("农历周年事件格式不正确")
+	}
+
+	r := LunarRecurrence{Leap: re[2] == "@"}
+	r.Month, _ = strconv.Atoi(re[1])
+	if re[3] != "" {
+		r.Day, _ = strconv.Atoi(re[3])
+	}
+
+	return r, r.check()
+}
+
+// (LunarRecurrence) check 检查月份和日期的范围
+func (r LunarRecurrence) check() error {
+	if r.Month < 1 || r.Month > 12 || r.Day < 0 || r.Day > 30 {
+		return &DateError{Op: "LunarRecurrence", Month: r.Month, Day: r.Day, Leap: r.Leap, Err: ErrInvalidDate}
+	}
+	return nil
+}
+
+// (*Calendar) LunarOccurrences 农历周年事件在start至end之间(包括start和end两日)的公历日期
+//
+// 返回的日期为日历时区该日的0时,按时间先后排列
+func (c *Calendar) LunarOccurrences(r LunarRecurrence, start, end time.Time) ([]time.Time, error) {
+	return c.lunarOccurrences(context.Background(), r, start, end)
+}
+
+// (*Calendar) lunarOccurrences 农历周年事件在start至end之间的公历日期,每算一年检查一次ctx
+func (c *Calendar) lunarOccurrences(ctx context.Context, r LunarRecurrence, start, end time.Time) ([]time.Time, error) {
+	if err := r.check(); err != nil {
+		return nil, err
+	}
+
+	start, end = start.In(c.loc), end.In(c.loc)
+	if start.After(end) {
+		start, end = end, start
+	}
+	sy, sm, sd := start.Date()
+	ey, em, ed := end.Date()
+	first := time.Date(sy, sm, sd, 0, 0, 0, 0, c.loc)
+	last := time.Date(ey, em, ed, 0, 0, 0, 0, c.loc)
+
+	var ts []time.Time
+
+	// 农历年的正月初一在公历1月下旬至2月中旬,腊月可以跨到下一公历年
+	for y := sy - 1; y <= ey; y++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		t, ok, err := c.lunarOccurrence(r, y)
+		if err != nil {
+			return nil, err
+		}
+		if !ok || t.Before(first) || t.After(last) {
+			continue
+		}
+		ts = append(ts, t)
+	}
+
+	return ts, nil
+}
+
+// (*Calendar) lunarOccurrence 农历周年事件在农历lunarYear年的公历日期
+//
+// 按规则该年没有这个事件时ok为false
+func (c *Calendar) lunarOccurrence(r LunarRecurrence, lunarYear int) (t time.Time, ok bool, err error) {
+	isLeap := r.Leap && c.LunarLeap(lunarYear) == r.Month
+	if r.Leap && !isLeap && r.LeapPolicy == LeapMonthSkip {
+		return time.Time{}, false, nil
+	}
+
+	days, err := c.LunarMonthDays(lunarYear, r.Month, isLeap)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	day, roll := r.Day, 0
+	if day == 0 {
+		day = days
+	} else if day > days {
+		switch r.DayPolicy {
+		case MissingDaySkip:
+			return time.Time{}, false, nil
+		case MissingDayNextMonth:
+			day, roll = days, day-days
+		default:
+			day = days
+		}
+	}
+
+	t, err = c.LunarToGregorian(lunarYear, r.Month, day, isLeap)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	return t.AddDate(0, 0, roll), true, nil
+}
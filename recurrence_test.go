@@ -0,0 +1,71 @@
+package gocalendar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLunarRecurrence(t *testing.T) {
+	r, err := ParseLunarRecurrence("4@M14D")
+	if err == nil && r.Month == 4 && r.Day == 14 && r.Leap {
+		t.Log("passed")
+	} else {
+		t.Error(r, err)
+	}
+
+	r, err = ParseLunarRecurrence("12M$")
+	if err == nil && r.Month == 12 && r.Day == 0 && !r.Leap {
+		t.Log("passed")
+	} else {
+		t.Error(r, err)
+	}
+
+	for _, k := range []string{"13M1D", "8M31D", "8M", "8M15D$"} {
+		if _, err := ParseLunarRecurrence(k); err == nil {
+			t.Error(k)
+		}
+	}
+}
+
+func TestCalendar_LunarOccurrences(t *testing.T) {
+	c := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai"})
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, c.loc)
+	end := time.Date(2023, 12, 31, 0, 0, 0, 0, c.loc)
+
+	check := func(r LunarRecurrence, start, end time.Time, want ...string) {
+		ts, err := c.LunarOccurrences(r, start, end)
+		if err != nil {
+			t.Error(r, err)
+			return
+		}
+		var got []string
+		for _, v := range ts {
+			got = append(got, v.Format("2006-01-02"))
+		}
+		if len(got) != len(want) {
+			t.Error(r, got)
+			return
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Error(r, got)
+				return
+			}
+		}
+		t.Log("passed")
+	}
+
+	// 中秋节
+	check(LunarRecurrence{Month: 8, Day: 15}, start, end, "2020-10-01", "2021-09-21", "2022-09-10", "2023-09-29")
+
+	// 闰四月十四,2020年有闰四月
+	check(LunarRecurrence{Month: 4, Day: 14, Leap: true}, start, end, "2020-06-05", "2021-05-25", "2022-05-14", "2023-06-01")
+	check(LunarRecurrence{Month: 4, Day: 14, Leap: true, LeapPolicy: LeapMonthSkip}, start, end, "2020-06-05")
+
+	// 2024年(甲辰)腊月只有29天
+	s, e := time.Date(2025, 1, 1, 0, 0, 0, 0, c.loc), time.Date(2025, 12, 31, 0, 0, 0, 0, c.loc)
+	check(LunarRecurrence{Month: 12, Day: 30}, s, e, "2025-01-28")
+	check(LunarRecurrence{Month: 12, Day: 30, DayPolicy: MissingDaySkip}, s, e)
+	check(LunarRecurrence{Month: 12, Day: 30, DayPolicy: MissingDayNextMonth}, s, e, "2025-01-29")
+	check(LunarRecurrence{Month: 12}, s, e, "2025-01-28")
+}
@@ -0,0 +1,257 @@
+package server
+
+// OpenAPIDocument 接口的OpenAPI 3.0文档,服务在/openapi.json提供
+const OpenAPIDocument = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "gocalendar",
+    "description": "公历、农历、节气、干支和星座",
+    "version": "1.0.0"
+  },
+  "paths": {
+    "/calendar": {
+      "get": {
+        "summary": "日历表",
+        "parameters": [
+          {"$ref": "#/components/parameters/date"},
+          {"name": "grid", "in": "query", "schema": {"type": "string", "enum": ["day", "week", "month", "year", "lunar_month", "solar_term_month"]}},
+          {"name": "first_week", "in": "query", "schema": {"type": "integer", "minimum": 0, "maximum": 6}},
+          {"$ref": "#/components/parameters/tz"},
+          {"$ref": "#/components/parameters/locale"},
+          {"$ref": "#/components/parameters/night_zi"},
+          {"name": "solar_terms", "in": "query", "schema": {"type": "boolean"}},
+          {"name": "lunar", "in": "query", "schema": {"type": "boolean"}},
+          {"name": "gz", "in": "query", "schema": {"type": "boolean"}},
+          {"name": "star_sign", "in": "query", "schema": {"type": "boolean"}},
+          {"name": "holiday", "in": "query", "schema": {"type": "boolean"}},
+          {"name": "moon", "in": "query", "schema": {"type": "boolean"}},
+          {"name": "sun", "in": "query", "schema": {"type": "boolean"}},
+          {"name": "true_solar_time", "in": "query", "schema": {"type": "boolean"}},
+          {"name": "almanac", "in": "query", "schema": {"type": "boolean"}},
+          {"name": "lunar_table", "in": "query", "schema": {"type": "boolean"}},
+          {"name": "hijri", "in": "query", "schema": {"type": "boolean"}},
+          {"name": "hijri_method", "in": "query", "schema": {"type": "string", "enum": ["tabular", "astronomical"]}},
+          {"name": "hebrew", "in": "query", "schema": {"type": "boolean"}},
+          {"name": "persian", "in": "query", "schema": {"type": "boolean"}},
+          {"name": "persian_method", "in": "query", "schema": {"type": "string", "enum": ["astronomical", "arithmetic"]}},
+          {"name": "lunar_variant", "in": "query", "schema": {"type": "string", "enum": ["chinese", "korean", "vietnamese"]}},
+          {"name": "historical_lunar", "in": "query", "schema": {"type": "boolean"}},
+          {"name": "era_names", "in": "query", "schema": {"type": "boolean"}},
+          {"name": "lat", "in": "query", "schema": {"type": "number"}},
+          {"name": "lon", "in": "query", "schema": {"type": "number"}},
+          {"name": "elevation", "in": "query", "schema": {"type": "number"}}
+        ],
+        "responses": {
+          "200": {"description": "日历单元,grid=year时为按月分组的MonthGrid", "content": {"application/json": {"schema": {"oneOf": [
+            {"type": "array", "items": {"$ref": "#/components/schemas/CalendarItem"}},
+            {"type": "array", "items": {"$ref": "#/components/schemas/MonthGrid"}}
+          ]}}}},
+          "304": {"$ref": "#/components/responses/NotModified"},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/lunar": {
+      "get": {
+        "summary": "公历转农历",
+        "parameters": [
+          {"$ref": "#/components/parameters/requiredDate"},
+          {"$ref": "#/components/parameters/tz"},
+          {"$ref": "#/components/parameters/locale"}
+        ],
+        "responses": {
+          "200": {"description": "农历", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/LunarDate"}}}},
+          "304": {"$ref": "#/components/responses/NotModified"},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/solar": {
+      "get": {
+        "summary": "农历转公历",
+        "parameters": [
+          {"name": "date", "in": "query", "required": true, "description": "农历日期 年-月-日", "schema": {"type": "string", "example": "2020-04-14"}},
+          {"name": "leap", "in": "query", "description": "是否闰月", "schema": {"type": "boolean"}}
+        ],
+        "responses": {
+          "200": {"description": "公历日期", "content": {"application/json": {"schema": {"type": "object", "properties": {"date": {"type": "string", "format": "date"}}}}}},
+          "304": {"$ref": "#/components/responses/NotModified"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/solarterms": {
+      "get": {
+        "summary": "一年的二十四节气",
+        "parameters": [
+          {"name": "year", "in": "query", "required": true, "schema": {"type": "integer"}},
+          {"$ref": "#/components/parameters/tz"},
+          {"$ref": "#/components/parameters/locale"}
+        ],
+        "responses": {
+          "200": {"description": "节气", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/SolarTermItem"}}}}},
+          "304": {"$ref": "#/components/responses/NotModified"},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/gz": {
+      "get": {
+        "summary": "干支",
+        "parameters": [
+          {"name": "time", "in": "query", "required": true, "schema": {"type": "string", "example": "2021-05-06T23:30"}},
+          {"$ref": "#/components/parameters/tz"},
+          {"$ref": "#/components/parameters/locale"},
+          {"$ref": "#/components/parameters/night_zi"},
+          {"name": "true_solar_time", "in": "query", "schema": {"type": "boolean"}},
+          {"name": "lon", "in": "query", "schema": {"type": "number"}}
+        ],
+        "responses": {
+          "200": {"description": "干支", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/GZ"}}}},
+          "304": {"$ref": "#/components/responses/NotModified"},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/starsign": {
+      "get": {
+        "summary": "星座",
+        "parameters": [
+          {"$ref": "#/components/parameters/requiredDate"},
+          {"$ref": "#/components/parameters/locale"}
+        ],
+        "responses": {
+          "200": {"description": "星座", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/StarSignItem"}}}},
+          "304": {"$ref": "#/components/responses/NotModified"},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    }
+  },
+  "components": {
+    "parameters": {
+      "date": {"name": "date", "in": "query", "description": "公历日期,默认今天", "schema": {"type": "string", "format": "date"}},
+      "requiredDate": {"name": "date", "in": "query", "required": true, "description": "公历日期", "schema": {"type": "string", "format": "date"}},
+      "tz": {"name": "tz", "in": "query", "description": "时区名称", "schema": {"type": "string", "example": "Asia/Shanghai"}},
+      "locale": {"name": "locale", "in": "query", "description": "语言", "schema": {"type": "string", "enum": ["zh-Hans", "zh-Hant", "en", "ja", "ko", "vi"]}},
+      "night_zi": {"name": "night_zi", "in": "query", "description": "区分早晚子时", "schema": {"type": "boolean"}}
+    },
+    "responses": {
+      "NotModified": {"description": "与If-None-Match的ETag相同"},
+      "Error": {"description": "错误", "content": {"application/json": {"schema": {"type": "object", "properties": {"error": {"type": "string"}}}}}}
+    },
+    "schemas": {
+      "GZItem": {
+        "type": "object",
+        "properties": {
+          "hsi": {"type": "integer"}, "hsn": {"type": "string"},
+          "ebi": {"type": "integer"}, "ebn": {"type": "string"}
+        }
+      },
+      "GZ": {
+        "type": "object",
+        "properties": {
+          "ygz": {"$ref": "#/components/schemas/GZItem"},
+          "mgz": {"$ref": "#/components/schemas/GZItem"},
+          "dgz": {"$ref": "#/components/schemas/GZItem"},
+          "hgz": {"$ref": "#/components/schemas/GZItem"},
+          "stime": {"type": "string", "format": "date-time", "nullable": true}
+        }
+      },
+      "FestivalItem": {
+        "type": "object",
+        "properties": {
+          "show": {"type": "array", "items": {"type": "string"}, "nullable": true},
+          "scdr": {"type": "array", "items": {"type": "string"}, "nullable": true}
+        }
+      },
+      "LunarDate": {
+        "type": "object",
+        "properties": {
+          "year": {"type": "integer"}, "month": {"type": "integer"}, "day": {"type": "integer"},
+          "monthName": {"type": "string"}, "dayName": {"type": "string"}, "leapStr": {"type": "string"},
+          "ylm": {"type": "integer"}, "sai": {"type": "integer"}, "san": {"type": "string"},
+          "ygz": {"$ref": "#/components/schemas/GZItem"},
+          "festival": {"$ref": "#/components/schemas/FestivalItem"},
+          "eras": {"type": "array", "items": {"$ref": "#/components/schemas/EraYear"}}
+        }
+      },
+      "EraYear": {
+        "type": "object",
+        "properties": {
+          "dynasty": {"type": "string"}, "emperor": {"type": "string"},
+          "name": {"type": "string"}, "year": {"type": "integer"}
+        }
+      },
+      "SolarTermItem": {
+        "type": "object",
+        "properties": {
+          "index": {"type": "integer"}, "name": {"type": "string"},
+          "time": {"type": "string", "format": "date-time"}
+        }
+      },
+      "StarSignItem": {
+        "type": "object",
+        "properties": {"index": {"type": "integer"}, "name": {"type": "string"}}
+      },
+      "CalendarItem": {
+        "type": "object",
+        "properties": {
+          "time": {"type": "string", "format": "date-time"},
+          "isam": {"type": "integer", "description": "0本月,-1上一月,1下一月"},
+          "istoday": {"type": "integer"},
+          "festival": {"$ref": "#/components/schemas/FestivalItem"},
+          "st": {"$ref": "#/components/schemas/SolarTermItem"},
+          "gz": {"$ref": "#/components/schemas/GZ"},
+          "ld": {"$ref": "#/components/schemas/LunarDate"},
+          "ss": {"$ref": "#/components/schemas/StarSignItem"},
+          "dt": {"type": "integer", "description": "日期类型"},
+          "dtn": {"type": "string", "description": "日期类型名称"},
+          "moon": {"type": "object", "nullable": true},
+          "sun": {"type": "object", "nullable": true},
+          "almanac": {"type": "object", "nullable": true},
+          "hijri": {"$ref": "#/components/schemas/HijriDate"},
+          "hebrew": {"$ref": "#/components/schemas/HebrewDate"},
+          "persian": {"$ref": "#/components/schemas/PersianDate"}
+        }
+      },
+      "HijriDate": {
+        "type": "object",
+        "nullable": true,
+        "properties": {
+          "year": {"type": "integer"}, "month": {"type": "integer"}, "day": {"type": "integer"},
+          "monthName": {"type": "string"}, "monthDays": {"type": "integer"},
+          "festival": {"$ref": "#/components/schemas/FestivalItem"}
+        }
+      },
+      "HebrewDate": {
+        "type": "object",
+        "nullable": true,
+        "properties": {
+          "year": {"type": "integer"}, "month": {"type": "integer"}, "day": {"type": "integer"},
+          "monthName": {"type": "string"}, "monthDays": {"type": "integer"}, "leap": {"type": "boolean"},
+          "festival": {"$ref": "#/components/schemas/FestivalItem"}
+        }
+      },
+      "PersianDate": {
+        "type": "object",
+        "nullable": true,
+        "properties": {
+          "year": {"type": "integer"}, "month": {"type": "integer"}, "day": {"type": "integer"},
+          "monthName": {"type": "string"}, "monthDays": {"type": "integer"}, "leap": {"type": "boolean"},
+          "nowruz": {"type": "string", "format": "date-time", "nullable": true},
+          "festival": {"$ref": "#/components/schemas/FestivalItem"}
+        }
+      },
+      "MonthGrid": {
+        "type": "object",
+        "properties": {
+          "year": {"type": "integer"}, "month": {"type": "integer"},
+          "items": {"type": "array", "items": {"$ref": "#/components/schemas/CalendarItem"}}
+        }
+      }
+    }
+  }
+}
+`
@@ -0,0 +1,212 @@
+package server
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/liujiawm/gocalendar"
+)
+
+// (*Server) newCalendar 用默认配置和查询参数新建日历
+//
+// 查询参数与CalendarConfig字段的对应:
+//
+//	grid=day|week|month|year|lunar_month|solar_term_month  Grid
+//	first_week=0-6          FirstWeek
+//	tz=Asia/Shanghai        TimeZoneName
+//	locale=zh-Hans          Locale
+//	solar_terms, lunar, gz, night_zi, star_sign, holiday, moon, sun, true_solar_time, almanac, lunar_table, hijri, hebrew, persian, historical_lunar, era_names  对应的bool字段
+//	hijri_method=tabular|astronomical  HijriMethod,没有lat、lon、elevation时astronomical按tabular计算
+//	persian_method=astronomical|arithmetic  PersianMethod
+//	lunar_variant=chinese|korean|vietnamese  LunarVariant
+//	lat, lon, elevation     Latitude, Longitude, Elevation,有其中之一时设置LocationSet
+func (s *Server) newCalendar(q url.Values) (*gocalendar.Calendar, *time.Location, error) {
+	cfg := s.base
+
+	if v := q.Get("grid"); v != "" {
+		switch v {
+		case "day":
+			cfg.Grid = gocalendar.GridDay
+		case "week":
+			cfg.Grid = gocalendar.GridWeek
+		case "month":
+			cfg.Grid = gocalendar.GridMonth
+		case "year":
+			cfg.Grid = gocalendar.GridYear
+		case "lunar_month":
+			cfg.Grid = gocalendar.GridLunarMonth
+		case "solar_term_month":
+			cfg.Grid = gocalendar.GridSolarTermMonth
+		default:
+			return nil, nil, badRequest("grid参数错误,应为day、week、month、year、lunar_month或solar_term_month")
+		}
+	}
+
+	if v := q.Get("first_week"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 || n > 6 {
+			return nil, nil, badRequest("first_week参数错误,应为0-6")
+		}
+		cfg.FirstWeek = n
+	}
+
+	if v := q.Get("tz"); v != "" {
+		if _, err := time.LoadLocation(v); err != nil {
+			return nil, nil, badRequest("tz参数错误: " + v)
+		}
+		cfg.TimeZoneName = v
+	}
+
+	if v := q.Get("locale"); v != "" {
+		if gocalendar.LookupLocale(v) == nil {
+			return nil, nil, badRequest("locale参数错误,可用: " + strings.Join(gocalendar.Locales(), ","))
+		}
+		cfg.Locale = v
+	}
+
+	bools := []struct {
+		name string
+		v    *bool
+	}{
+		{"solar_terms", &cfg.SolarTerms},
+		{"lunar", &cfg.Lunar},
+		{"gz", &cfg.HeavenlyEarthly},
+		{"night_zi", &cfg.NightZiHour},
+		{"star_sign", &cfg.StarSign},
+		{"holiday", &cfg.Holiday},
+		{"moon", &cfg.MoonPhase},
+		{"sun", &cfg.Sun},
+		{"true_solar_time", &cfg.TrueSolarTime},
+		{"almanac", &cfg.Almanac},
+		{"lunar_table", &cfg.LunarTable},
+		{"hijri", &cfg.Hijri},
+		{"hebrew", &cfg.Hebrew},
+		{"persian", &cfg.Persian},
+		{"historical_lunar", &cfg.HistoricalLunar},
+		{"era_names", &cfg.EraNames},
+	}
+	for _, b := range bools {
+		v, err := boolParam(q, b.name, *b.v)
+		if err != nil {
+			return nil, nil, err
+		}
+		*b.v = v
+	}
+
+	if v := q.Get("hijri_method"); v != "" {
+		switch v {
+		case "tabular":
+			cfg.HijriMethod = gocalendar.HijriTabular
+		case "astronomical":
+			cfg.HijriMethod = gocalendar.HijriAstronomical
+		default:
+			return nil, nil, badRequest("hijri_method参数错误,应为tabular或astronomical")
+		}
+	}
+
+	if v := q.Get("persian_method"); v != "" {
+		switch v {
+		case "astronomical":
+			cfg.PersianMethod = gocalendar.PersianAstronomical
+		case "arithmetic":
+			cfg.PersianMethod = gocalendar.PersianArithmetic
+		default:
+			return nil, nil, badRequest("persian_method参数错误,应为astronomical或arithmetic")
+		}
+	}
+
+	if v := q.Get("lunar_variant"); v != "" {
+		switch v {
+		case "chinese":
+			cfg.LunarVariant = gocalendar.ChineseLunar
+		case "korean":
+			cfg.LunarVariant = gocalendar.KoreanLunar
+		case "vietnamese":
+			cfg.LunarVariant = gocalendar.VietnameseLunar
+		default:
+			return nil, nil, badRequest("lunar_variant参数错误,应为chinese、korean或vietnamese")
+		}
+	}
+
+	floats := []struct {
+		name string
+		v    *float64
+	}{
+		{"lat", &cfg.Latitude},
+		{"lon", &cfg.Longitude},
+		{"elevation", &cfg.Elevation},
+	}
+	for _, f := range floats {
+		if v := q.Get(f.name); v != "" {
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, nil, badRequest(f.name + "参数错误")
+			}
+			*f.v = n
+			cfg.LocationSet = true
+		}
+	}
+
+	c := gocalendar.NewCalendar(cfg)
+	loc, err := time.LoadLocation(c.GetConfig().TimeZoneName)
+	if err != nil {
+		loc = time.Local
+	}
+	return c, loc, nil
+}
+
+// boolParam 读bool参数,没有该参数时返回def
+func boolParam(q url.Values, name string, def bool) (bool, error) {
+	v := q.Get(name)
+	if v == "" {
+		return def, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, badRequest(name + "参数错误,应为true或false")
+	}
+	return b, nil
+}
+
+// parseDateTime 解析loc时区的日期时间
+func parseDateTime(s string, loc *time.Location) (time.Time, error) {
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, badRequest("日期时间格式错误: " + s)
+}
+
+// requiredDateTime 读必需的日期时间参数
+func requiredDateTime(q url.Values, name string, loc *time.Location) (time.Time, error) {
+	v := q.Get(name)
+	if v == "" {
+		return time.Time{}, badRequest("缺少" + name + "参数")
+	}
+	return parseDateTime(v, loc)
+}
+
+// parseLunarDate 解析农历日期 年-月-日,如2021-12-30,不检查公历中是否有该日
+func parseLunarDate(s string) (year, month, day int, err error) {
+	neg := strings.HasPrefix(s, "-")
+	parts := strings.Split(strings.TrimPrefix(s, "-"), "-")
+	if len(parts) == 3 {
+		year, err = strconv.Atoi(parts[0])
+		if err == nil {
+			month, err = strconv.Atoi(parts[1])
+		}
+		if err == nil {
+			day, err = strconv.Atoi(parts[2])
+		}
+		if err == nil {
+			if neg {
+				year = -year
+			}
+			return year, month, day, nil
+		}
+	}
+	return 0, 0, 0, badRequest("date参数错误,应为 年-月-日")
+}
@@ -0,0 +1,285 @@
+// Package server 以HTTP/JSON接口提供日历服务
+//
+// 所有接口只接受GET请求,查询参数对应gocalendar.CalendarConfig的字段,返回与gocalendar中类型一致的JSON。
+// 相同的请求返回相同的ETag,客户端可以用If-None-Match避免重复传输。
+package server
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/liujiawm/gocalendar"
+)
+
+// 缓存时间,日历表中有"今天"标记,不宜缓存太久
+const cMaxAge = 3600
+
+// 接受的日期时间格式
+var dateTimeLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+// type Server struct 日历服务
+type Server struct {
+	base gocalendar.CalendarConfig
+	mux  *http.ServeMux
+}
+
+// type errorBody struct 出错时返回的JSON
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+// type solarDate struct 农历转公历的结果
+type solarDate struct {
+	Date string `json:"date"`
+}
+
+// type requestError struct 请求参数错误
+type requestError struct {
+	msg string
+}
+
+func (e *requestError) Error() string {
+	return e.msg
+}
+
+// badRequest 请求参数错误
+func badRequest(msg string) error {
+	return &requestError{msg: msg}
+}
+
+// New 新建日历服务,base为默认配置,请求的查询参数在其基础上修改
+func New(base gocalendar.CalendarConfig) *Server {
+	s := &Server{base: base, mux: http.NewServeMux()}
+
+	s.handle("/calendar", s.calendar)
+	s.handle("/lunar", s.lunar)
+	s.handle("/solar", s.solar)
+	s.handle("/solarterms", s.solarTerms)
+	s.handle("/gz", s.gz)
+	s.handle("/starsign", s.starSign)
+	s.mux.HandleFunc("/openapi.json", s.openAPI)
+
+	return s
+}
+
+// (*Server) ServeHTTP 实现http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// (*Server) handle 注册接口,h返回要输出为JSON的值
+func (s *Server) handle(pattern string, h func(r *http.Request, q url.Values) (interface{}, error)) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			writeJSON(w, r, http.StatusMethodNotAllowed, errorBody{Error: "只支持GET请求"})
+			return
+		}
+
+		v, err := h(r, r.URL.Query())
+		if err != nil {
+			writeJSON(w, r, statusOf(err), errorBody{Error: err.Error()})
+			return
+		}
+		writeJSON(w, r, http.StatusOK, v)
+	})
+}
+
+// statusOf 错误对应的HTTP状态码
+func statusOf(err error) int {
+	var re *requestError
+	switch {
+	case errors.As(err, &re):
+		return http.StatusBadRequest
+	case errors.Is(err, gocalendar.ErrInvalidDate), errors.Is(err, gocalendar.ErrOutOfRange):
+		return http.StatusBadRequest
+	case errors.Is(err, gocalendar.ErrNoLeapMonth), errors.Is(err, gocalendar.ErrDayOutOfMonth):
+		return http.StatusNotFound
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// writeJSON 输出JSON,成功的响应带ETag和缓存头
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		status = http.StatusInternalServerError
+		body, _ = json.Marshal(errorBody{Error: err.Error()})
+	}
+	body = append(body, '\n')
+
+	h := w.Header()
+	h.Set("Content-Type", "application/json; charset=utf-8")
+
+	if status == http.StatusOK {
+		sum := sha1.Sum(body)
+		etag := `"` + hex.EncodeToString(sum[:]) + `"`
+		h.Set("ETag", etag)
+		h.Set("Cache-Control", "public, max-age="+strconv.Itoa(cMaxAge))
+		if etagMatch(r.Header.Values("If-None-Match"), etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	h.Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(status)
+	if r.Method != http.MethodHead {
+		_, _ = w.Write(body)
+	}
+}
+
+// etagMatch If-None-Match是否与etag匹配
+//
+// 按RFC 7232弱比较:可以是逗号分隔的多个值或多行,W/前缀的弱验证器与强验证器同等对待,*匹配任何etag
+func etagMatch(values []string, etag string) bool {
+	for _, v := range values {
+		for _, tag := range strings.Split(v, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// (*Server) calendar 日历表 GET /calendar?date=2021-05-06&grid=month
+func (s *Server) calendar(r *http.Request, q url.Values) (interface{}, error) {
+	c, loc, err := s.newCalendar(q)
+	if err != nil {
+		return nil, err
+	}
+
+	t := time.Now().In(loc)
+	if q.Get("date") != "" {
+		if t, err = parseDateTime(q.Get("date"), loc); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.Context().Err(); err != nil {
+		return nil, err
+	}
+
+	// 年日历表按月分组返回
+	if c.GetConfig().Grid == gocalendar.GridYear {
+		c.SetRawTime(t.Year(), int(t.Month()), t.Day())
+		return c.YearCalendar(), nil
+	}
+
+	return c.GenerateWithDate(t.Year(), int(t.Month()), t.Day()), nil
+}
+
+// (*Server) lunar 公历转农历 GET /lunar?date=2021-05-06
+func (s *Server) lunar(r *http.Request, q url.Values) (interface{}, error) {
+	c, loc, err := s.newCalendar(q)
+	if err != nil {
+		return nil, err
+	}
+	t, err := requiredDateTime(q, "date", loc)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GregorianToLunarE(r.Context(), t.Year(), int(t.Month()), t.Day())
+}
+
+// (*Server) solar 农历转公历 GET /solar?date=2020-04-14&leap=true
+func (s *Server) solar(r *http.Request, q url.Values) (interface{}, error) {
+	c, _, err := s.newCalendar(q)
+	if err != nil {
+		return nil, err
+	}
+
+	year, month, day, err := parseLunarDate(q.Get("date"))
+	if err != nil {
+		return nil, err
+	}
+	leap, err := boolParam(q, "leap", false)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := c.LunarToGregorianE(r.Context(), year, month, day, leap)
+	if err != nil {
+		return nil, err
+	}
+	return solarDate{Date: t.Format("2006-01-02")}, nil
+}
+
+// (*Server) solarTerms 一年的节气 GET /solarterms?year=2021
+func (s *Server) solarTerms(r *http.Request, q url.Values) (interface{}, error) {
+	c, _, err := s.newCalendar(q)
+	if err != nil {
+		return nil, err
+	}
+	year, err := strconv.Atoi(q.Get("year"))
+	if err != nil {
+		return nil, badRequest("year参数错误")
+	}
+
+	sts, err := c.SolarTermsE(r.Context(), year)
+	if err != nil {
+		return nil, err
+	}
+
+	// SolarTerms包含上一年的冬至和下一年的小寒,只取该年的
+	terms := []*gocalendar.SolarTermItem{}
+	for _, st := range sts {
+		if st.Time.Year() == year {
+			terms = append(terms, st)
+		}
+	}
+	return terms, nil
+}
+
+// (*Server) gz 干支 GET /gz?time=2021-05-06T23:30
+func (s *Server) gz(r *http.Request, q url.Values) (interface{}, error) {
+	c, loc, err := s.newCalendar(q)
+	if err != nil {
+		return nil, err
+	}
+	t, err := requiredDateTime(q, "time", loc)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ChineseSexagenaryCycleE(r.Context(), t)
+}
+
+// (*Server) starSign 星座 GET /starsign?date=2021-05-06
+func (s *Server) starSign(r *http.Request, q url.Values) (interface{}, error) {
+	c, loc, err := s.newCalendar(q)
+	if err != nil {
+		return nil, err
+	}
+	t, err := requiredDateTime(q, "date", loc)
+	if err != nil {
+		return nil, err
+	}
+
+	i, _, err := gocalendar.StarSign(int(t.Month()), t.Day())
+	if err != nil {
+		return nil, err
+	}
+	l := gocalendar.LookupLocale(c.GetConfig().Locale)
+	return &gocalendar.StarSignItem{Index: i, Name: l.StarSigns[i]}, nil
+}
+
+// (*Server) openAPI OpenAPI文档 GET /openapi.json
+func (s *Server) openAPI(w http.ResponseWriter, r *http.Request) {
+	h := w.Header()
+	h.Set("Content-Type", "application/json; charset=utf-8")
+	h.Set("Cache-Control", "public, max-age="+strconv.Itoa(cMaxAge))
+	_, _ = w.Write([]byte(OpenAPIDocument))
+}
@@ -0,0 +1,180 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/liujiawm/gocalendar"
+)
+
+func newTestServer() *Server {
+	return New(gocalendar.CalendarConfig{
+		Grid:            gocalendar.GridMonth,
+		TimeZoneName:    "Asia/Shanghai",
+		SolarTerms:      true,
+		Lunar:           true,
+		HeavenlyEarthly: true,
+		StarSign:        true,
+	})
+}
+
+// get 请求url,返回响应
+func get(s *Server, url string, header ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_Calendar(t *testing.T) {
+	s := newTestServer()
+
+	rec := get(s, "/calendar?date=2021-05-06&first_week=1")
+	var items []*gocalendar.CalendarItem
+	if err := json.Unmarshal(rec.Body.Bytes(), &items); err != nil {
+		t.Fatal(rec.Code, err)
+	}
+	// 2021年5月1日是周六,第一列是周一时日历表从4月26日开始
+	if rec.Code == http.StatusOK && len(items) == 42 && items[0].Time.Format("2006-01-02") == "2021-04-26" && items[10].LunarDate.Day == 25 {
+		t.Log("passed")
+	} else {
+		t.Error(rec.Code, len(items))
+	}
+
+	rec = get(s, "/calendar?date=2021-05-06&grid=day&gz=false")
+	items = nil
+	_ = json.Unmarshal(rec.Body.Bytes(), &items)
+	if len(items) == 1 && items[0].GZ == nil && items[0].LunarDate != nil {
+		t.Log("passed")
+	} else {
+		t.Error(rec.Body.String())
+	}
+
+	rec = get(s, "/calendar?date=2021-05-06&grid=year")
+	var grids []*gocalendar.MonthGrid
+	_ = json.Unmarshal(rec.Body.Bytes(), &grids)
+	if len(grids) == 12 && grids[11].Month == 12 && len(grids[11].Items) == 42 {
+		t.Log("passed")
+	} else {
+		t.Error(rec.Code, len(grids))
+	}
+}
+
+func TestServer_ETag(t *testing.T) {
+	s := newTestServer()
+
+	rec := get(s, "/lunar?date=2021-05-06")
+	etag := rec.Header().Get("ETag")
+	if rec.Code == http.StatusOK && etag != "" && rec.Header().Get("Cache-Control") != "" {
+		t.Log("passed")
+	} else {
+		t.Error(rec.Code, rec.Header())
+	}
+
+	rec = get(s, "/lunar?date=2021-05-06", "If-None-Match", etag)
+	if rec.Code == http.StatusNotModified && rec.Body.Len() == 0 {
+		t.Log("passed")
+	} else {
+		t.Error(rec.Code)
+	}
+
+	// 多个值、弱验证器和*
+	tests := []struct {
+		inm  string
+		code int
+	}{
+		{`"a", ` + etag + `, "b"`, http.StatusNotModified},
+		{"W/" + etag, http.StatusNotModified},
+		{`"a",W/` + etag, http.StatusNotModified},
+		{"*", http.StatusNotModified},
+		{`"a", W/"b"`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		if rec := get(s, "/lunar?date=2021-05-06", "If-None-Match", tt.inm); rec.Code == tt.code {
+			t.Log("passed")
+		} else {
+			t.Error(tt.inm, rec.Code)
+		}
+	}
+}
+
+func TestServer_Conversions(t *testing.T) {
+	s := newTestServer()
+
+	tests := []struct {
+		url  string
+		code int
+		want string
+	}{
+		{"/solar?date=2020-04-14&leap=true", http.StatusOK, `{"date":"2020-06-05"}`},
+		{"/solar?date=2021-04-14&leap=true", http.StatusNotFound, ""},
+		{"/solar?date=2021-04", http.StatusBadRequest, ""},
+		{"/lunar?date=2021-02-30", http.StatusBadRequest, ""},
+		{"/lunar", http.StatusBadRequest, ""},
+		{"/starsign?date=2021-05-06", http.StatusOK, `{"index":3,"name":"金牛"}`},
+		{"/starsign?date=2021-05-06&locale=en", http.StatusOK, `{"index":3,"name":"Taurus"}`},
+		{"/solarterms?year=3001", http.StatusBadRequest, ""},
+		{"/calendar?tz=Nowhere/City", http.StatusBadRequest, ""},
+		{"/calendar?grid=decade", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		rec := get(s, tt.url)
+		body := rec.Body.String()
+		if rec.Code == tt.code && (tt.want == "" || body == tt.want+"\n") {
+			t.Log("passed")
+		} else {
+			t.Error(tt.url, rec.Code, body)
+		}
+	}
+
+	var gz gocalendar.GZ
+	rec := get(s, "/gz?time=2021-05-06T23:30&night_zi=true")
+	_ = json.Unmarshal(rec.Body.Bytes(), &gz)
+	if gz.Day != nil && gz.Day.HSN+gz.Day.EBN == "甲寅" && gz.Hour.HSN+gz.Hour.EBN == "丙子" {
+		t.Log("passed")
+	} else {
+		t.Error(rec.Body.String())
+	}
+
+	var terms []*gocalendar.SolarTermItem
+	rec = get(s, "/solarterms?year=2021")
+	_ = json.Unmarshal(rec.Body.Bytes(), &terms)
+	if len(terms) == 24 && terms[0].Name == "小寒" {
+		t.Log("passed")
+	} else {
+		t.Error(rec.Body.String())
+	}
+}
+
+func TestServer_OpenAPI(t *testing.T) {
+	s := newTestServer()
+
+	rec := get(s, "/openapi.json")
+	var doc struct {
+		OpenAPI string                 `json:"openapi"`
+		Paths   map[string]interface{} `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.OpenAPI == "3.0.3" && len(doc.Paths) == 6 {
+		t.Log("passed")
+	} else {
+		t.Error(doc.OpenAPI, len(doc.Paths))
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/lunar?date=2021-05-06", nil)
+	rr := httptest.NewRecorder()
+	s.ServeHTTP(rr, req)
+	if rr.Code == http.StatusMethodNotAllowed {
+		t.Log("passed")
+	} else {
+		t.Error(rr.Code)
+	}
+}
@@ -0,0 +1,141 @@
+package gocalendar
+
+import (
+	"time"
+)
+
+// type SolarTermMonth struct 节气月,以节为月首,如立春至惊蛰前一日为寅月
+type SolarTermMonth struct {
+	Year  int             `json:"year"`  // 节气年,以立春为岁首
+	Index int             `json:"index"` // 0为寅月(立春起),1为卯月(惊蛰起)...11为丑月(小寒起)
+	GZ    *GZItem         `json:"mgz"`   // 月干支,与ChineseSexagenaryCycle的月柱相同
+	Start *SolarTermItem  `json:"start"` // 该月开始的节
+	End   *SolarTermItem  `json:"end"`   // 下一个节,该月到此前一日为止
+	Items []*CalendarItem `json:"items"` // 日历表,共6周42天,IsAccidental相对于该节气月
+}
+
+// (*Calendar) SolarTermMonth 节气年year第index个节气月,index为0至11,0为寅月
+//
+// rawTime设为该月开始的节当天,index不在0至11时返回ErrInvalidDate,超出计算范围时返回ErrOutOfRange
+func (c *Calendar) SolarTermMonth(year, index int) (*SolarTermMonth, error) {
+	err := checkLunarYear(year)
+	if index < 0 || index > 11 {
+		err = ErrInvalidDate
+	}
+	if err != nil {
+		return nil, &DateError{Op: "SolarTermMonth", Year: year, Month: index + 1, Err: err}
+	}
+
+	stm := c.newSolarTermMonth(year, index)
+	c.setRawTime(*stm.Start.Time)
+	stm.Items = c.spanItems(c.solarTermMonthSpan(stm))
+	c.Items = stm.Items
+
+	return stm, nil
+}
+
+// (*Calendar) NextSolarTermMonth rawTime所在节气月的下一个节气月,丑月的下一月为下一节气年的寅月
+//
+// 超出计算范围时返回ErrOutOfRange,rawTime不变
+func (c *Calendar) NextSolarTermMonth() (*SolarTermMonth, error) {
+	year, index := c.solarTermMonthOf(c.GetRawTime())
+	if index++; index > 11 {
+		year, index = year+1, 0
+	}
+	return c.SolarTermMonth(year, index)
+}
+
+// (*Calendar) PreviousSolarTermMonth rawTime所在节气月的上一个节气月,寅月的上一月为上一节气年的丑月
+//
+// 超出计算范围时返回ErrOutOfRange,rawTime不变
+func (c *Calendar) PreviousSolarTermMonth() (*SolarTermMonth, error) {
+	year, index := c.solarTermMonthOf(c.GetRawTime())
+	if index--; index < 0 {
+		year, index = year-1, 11
+	}
+	return c.SolarTermMonth(year, index)
+}
+
+// (*Calendar) solarTermMonthCalendar rawTime所在节气月的日历表
+func (c *Calendar) solarTermMonthCalendar() []*CalendarItem {
+	year, index := c.solarTermMonthOf(c.GetRawTime())
+	stm := c.newSolarTermMonth(year, index)
+
+	// 附值给Calendar.Items
+	c.Items = c.spanItems(c.solarTermMonthSpan(stm))
+
+	return c.Items
+}
+
+// (*Calendar) newSolarTermMonth 节气月的月干支和起止的节,不含日历表
+func (c *Calendar) newSolarTermMonth(year, index int) *SolarTermMonth {
+	// 月干支,寅月的月干由年干推出(甲己之年丙作首)
+	mgz := ((year+4712)*12 + index + 50) % 60
+
+	nextYear, nextIndex := year, index+1
+	if nextIndex > 11 {
+		nextYear, nextIndex = year+1, 0
+	}
+
+	return &SolarTermMonth{
+		Year:  year,
+		Index: index,
+		GZ:    c.locale().gzItem(mgz%10, mgz%12),
+		Start: c.solarTermMonthJie(year, index),
+		End:   c.solarTermMonthJie(nextYear, nextIndex),
+	}
+}
+
+// (*Calendar) solarTermMonthJie 节气年year第index个节气月开始的节,返回缓存中节气的副本
+func (c *Calendar) solarTermMonthJie(year, index int) *SolarTermItem {
+	// 立春的索引为21,之后每隔一个节气是一个节
+	stIndex := (21 + index*2) % 24
+
+	// 小寒在下一个公历年
+	gYear := year
+	if index == 11 {
+		gYear++
+	}
+
+	sts, _ := c.solarTerms(gYear)
+	for _, st := range sts {
+		if st.Index == stIndex && st.Time.Year() == gYear {
+			return st.clone()
+		}
+	}
+	return nil
+}
+
+// (*Calendar) solarTermMonthSpan 节气月的第一日和最后一日(c.loc时区的零点)
+//
+// 与ChineseSexagenaryCycle相同,以节当天为该月第一日,不考虑节的时分秒
+func (c *Calendar) solarTermMonthSpan(stm *SolarTermMonth) (time.Time, time.Time) {
+	return c.dateOf(*stm.Start.Time), c.dateOf(*stm.End.Time).AddDate(0, 0, -1)
+}
+
+// (*Calendar) solarTermMonthOf t所在的节气月
+func (c *Calendar) solarTermMonthOf(t time.Time) (year, index int) {
+	d := c.dateOf(t)
+	y := d.Year()
+
+	// 1月初大雪所在的月至12月大雪所在的月
+	year, index = y-1, 10
+	for i := -1; i <= 10; i++ {
+		cy, ci := y, i
+		if i < 0 {
+			cy, ci = y-1, 11
+		}
+		if c.dateOf(*c.solarTermMonthJie(cy, ci).Time).After(d) {
+			break
+		}
+		year, index = cy, ci
+	}
+
+	return year, index
+}
+
+// (*Calendar) dateOf t在c.loc时区当天的零点
+func (c *Calendar) dateOf(t time.Time) time.Time {
+	year, month, day := t.In(c.loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, c.loc)
+}
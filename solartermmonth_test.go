@@ -0,0 +1,117 @@
+package gocalendar
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCalendar_SolarTermMonth(t *testing.T) {
+	c := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai", HeavenlyEarthly: true})
+
+	// 2021辛丑年寅月庚寅,立春2021-02-03至惊蛰2021-03-05
+	stm, err := c.SolarTermMonth(2021, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stm.GZ.HSN+stm.GZ.EBN == "庚寅" && stm.Start.Name == "立春" && stm.End.Name == "惊蛰" &&
+		stm.Start.Time.Format("2006-01-02") == "2021-02-03" && stm.End.Time.Format("2006-01-02") == "2021-03-05" {
+		t.Log("passed")
+	} else {
+		t.Error(stm.GZ, stm.Start, stm.End)
+	}
+
+	// 逐月前进两年,跨公历年和节气年,每月的日期连续且月柱与ChineseSexagenaryCycle相同
+	var last *CalendarItem
+	for i := 0; i < 24; i++ {
+		var cur []*CalendarItem
+		for _, item := range stm.Items {
+			if item.IsAccidental == 0 {
+				cur = append(cur, item)
+			}
+		}
+		if len(cur) < 29 || len(cur) > 32 {
+			t.Fatalf("%d-%d: %d days", stm.Year, stm.Index, len(cur))
+		}
+		if last != nil && !last.Time.AddDate(0, 0, 1).Equal(*cur[0].Time) {
+			t.Errorf("%d-%d starts %s, previous ended %s", stm.Year, stm.Index, cur[0].Time, last.Time)
+		}
+		for _, item := range cur {
+			if *item.GZ.Month != *stm.GZ {
+				t.Errorf("%s month GZ %v, want %v", item.Time.Format("2006-01-02"), item.GZ.Month, stm.GZ)
+			}
+		}
+		last = cur[len(cur)-1]
+		if stm, err = c.NextSolarTermMonth(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if stm.Year == 2023 && stm.Index == 0 {
+		t.Log("passed")
+	} else {
+		t.Error(stm.Year, stm.Index)
+	}
+
+	// 2022-01-01在2021年子月(大雪起),上一月为亥月
+	c.SetRawTime(2022, 1, 1)
+	stm, err = c.PreviousSolarTermMonth()
+	if err == nil && stm.Year == 2021 && stm.Index == 9 && stm.Start.Name == "立冬" {
+		t.Log("passed")
+	} else {
+		t.Error(stm.Year, stm.Index, stm.Start)
+	}
+
+	if _, err := c.SolarTermMonth(2021, 12); !errors.Is(err, ErrInvalidDate) {
+		t.Error(err)
+	}
+	if _, err := c.SolarTermMonth(MaxYear, 0); !errors.Is(err, ErrOutOfRange) {
+		t.Error(err)
+	}
+
+	// 计算范围的边界,返回错误而不是nil
+	if _, err := c.SolarTermMonth(MaxYear-1, 11); err != nil {
+		t.Fatal(err)
+	}
+	if stm, err := c.NextSolarTermMonth(); stm == nil && errors.Is(err, ErrOutOfRange) {
+		t.Log("passed")
+	} else {
+		t.Error(stm, err)
+	}
+	if _, err := c.SolarTermMonth(MinYear, 0); err != nil {
+		t.Fatal(err)
+	}
+	if stm, err := c.PreviousSolarTermMonth(); stm == nil && errors.Is(err, ErrOutOfRange) {
+		t.Log("passed")
+	} else {
+		t.Error(stm, err)
+	}
+
+	// Start和End是副本,修改不影响节气缓存
+	stm, _ = c.SolarTermMonth(2021, 0)
+	stm.Start.Name = "x"
+	if sts := c.SolarTerms(2021); sts[3].Name == "立春" {
+		t.Log("passed")
+	} else {
+		t.Error(sts[3])
+	}
+}
+
+func TestCalendar_GenerateSolarTermMonth(t *testing.T) {
+	c := NewCalendar(CalendarConfig{Grid: GridSolarTermMonth, TimeZoneName: "Asia/Shanghai"})
+
+	// 2021-05-06在巳月,立夏2021-05-05至芒种前一日2021-06-04
+	items := c.GenerateWithDate(2021, 5, 6)
+	var first, last *CalendarItem
+	for _, item := range items {
+		if item.IsAccidental == 0 {
+			if first == nil {
+				first = item
+			}
+			last = item
+		}
+	}
+	if len(items) == 42 && first.Time.Format("2006-01-02") == "2021-05-05" && last.Time.Format("2006-01-02") == "2021-06-04" {
+		t.Log("passed")
+	} else {
+		t.Error(len(items), first.Time, last.Time)
+	}
+}
@@ -0,0 +1,217 @@
+package gocalendar
+
+import (
+	"math"
+	"time"
+)
+
+// 日出日落及晨昏蒙影对应的太阳高度角(度)
+const (
+	cSunriseAltitude      float64 = -0.8333 // 日出日落,太阳上缘与地平线相切,已计入大气折射和太阳视半径
+	cCivilTwilight        float64 = -6      // 民用晨昏蒙影
+	cNauticalTwilight     float64 = -12     // 航海晨昏蒙影
+	cAstronomicalTwilight float64 = -18     // 天文晨昏蒙影
+)
+
+// type SunItem struct 日出日落
+//
+// 不会发生的事件(如极昼时的日落)为nil
+type SunItem struct {
+	Sunrise          *time.Time `json:"sunrise"`    // 日出
+	SolarNoon        *time.Time `json:"noon"`       // 太阳上中天(正午)
+	Sunset           *time.Time `json:"sunset"`     // 日落
+	CivilDawn        *time.Time `json:"cdawn"`      // 民用晨光始
+	CivilDusk        *time.Time `json:"cdusk"`      // 民用昏影终
+	NauticalDawn     *time.Time `json:"ndawn"`      // 航海晨光始
+	NauticalDusk     *time.Time `json:"ndusk"`      // 航海昏影终
+	AstronomicalDawn *time.Time `json:"adawn"`      // 天文晨光始
+	AstronomicalDusk *time.Time `json:"adusk"`      // 天文昏影终
+	PolarDay         bool       `json:"polarday"`   // 极昼,太阳整天不落
+	PolarNight       bool       `json:"polarnight"` // 极夜,太阳整天不升
+}
+
+// solarPosition 儒略日(UT)jd时太阳的赤纬(度)和时差(分钟)
+//
+// 算法摘自Jean Meeus《Astronomical Algorithms》第25章 Solar Coordinates 的低精度公式
+// 和第28章 Equation of Time,精度约0.01度和数秒
+func solarPosition(jd float64) (declination, eot float64) {
+	tm := JdToTimeMap(jd)
+	dtd, _ := deltaTDays(float64(tm["year"]), float64(tm["month"])) // 超出有效范围时为外推值
+	t := julianCentury(jd + dtd)
+
+	pi180 := math.Pi / 180
+
+	// 太阳几何平黄经
+	l0 := math.Mod(280.46646+36000.76983*t+0.0003032*t*t, 360)
+
+	// 太阳平近点角
+	m := 357.52911 + 35999.05029*t - 0.0001537*t*t
+
+	// 地球轨道离心率
+	e := 0.016708634 - 0.000042037*t - 0.0000001267*t*t
+
+	// 太阳中心差
+	c := (1.914602-0.004817*t-0.000014*t*t)*math.Sin(pi180*m) +
+		(0.019993-0.000101*t)*math.Sin(pi180*2*m) +
+		0.000289*math.Sin(pi180*3*m)
+
+	// 太阳视黄经
+	omega := 125.04 - 1934.136*t
+	lambda := l0 + c - 0.00569 - 0.00478*math.Sin(pi180*omega)
+
+	// 黄赤交角
+	eps0 := 23 + (26+(21.448-t*(46.815+t*(0.00059-t*0.001813)))/60)/60
+	eps := eps0 + 0.00256*math.Cos(pi180*omega)
+
+	declination = math.Asin(math.Sin(pi180*eps)*math.Sin(pi180*lambda)) / pi180
+
+	y := math.Pow(math.Tan(pi180*eps/2), 2)
+	et := y*math.Sin(2*pi180*l0) - 2*e*math.Sin(pi180*m) + 4*e*y*math.Sin(pi180*m)*math.Cos(2*pi180*l0) -
+		0.5*y*y*math.Sin(4*pi180*l0) - 1.25*e*e*math.Sin(2*pi180*m)
+
+	eot = 4 * et / pi180
+
+	return declination, eot
+}
+
+// equationOfTime 儒略日(UT)jd时的时差(分钟),即真太阳时减平太阳时
+func equationOfTime(jd float64) float64 {
+	_, eot := solarPosition(jd)
+	return eot
+}
+
+// transitJd0 最接近noon(UT儒略日)的经度longitude处太阳上中天所在那一天0时(UT)的儒略日
+//
+// 当地日期与UT日期可能相差一天,如东十四区,因此用当地日期12时的UT儒略日选取上中天
+func transitJd0(noon, longitude float64) float64 {
+	return math.Floor(noon+longitude/360-0.5) + 0.5
+}
+
+// solarNoonJd 经度longitude处最接近noon(当地日期12时的UT儒略日)的太阳上中天的儒略日(UT)
+func solarNoonJd(noon, longitude float64) float64 {
+	jd0 := transitJd0(noon, longitude)
+	noon = jd0 + 0.5 - longitude/360
+	for i := 0; i < 2; i++ {
+		noon = jd0 + 0.5 - longitude/360 - equationOfTime(noon)/1440
+	}
+	return noon
+}
+
+// sunAltitudeJd 太阳高度为altitude(度)的时刻(UT儒略日)
+//
+// rising为true时求上午(升),否则求下午(落);该日太阳高度始终高于altitude时polar为1,始终低于altitude时polar为-1
+//
+// noon为当地日期12时的UT儒略日,按最接近它的太阳上中天计算
+func sunAltitudeJd(noon, latitude, longitude, altitude float64, rising bool) (jd float64, polar int) {
+	pi180 := math.Pi / 180
+
+	jd0 := transitJd0(noon, longitude)
+	jd = solarNoonJd(noon, longitude)
+	for i := 0; i < 3; i++ {
+		decl, eot := solarPosition(jd)
+		cosH := (math.Sin(pi180*altitude) - math.Sin(pi180*latitude)*math.Sin(pi180*decl)) /
+			(math.Cos(pi180*latitude) * math.Cos(pi180*decl))
+		if cosH < -1 {
+			return 0, 1
+		}
+		if cosH > 1 {
+			return 0, -1
+		}
+
+		h := math.Acos(cosH) / pi180 / 360
+		noon := jd0 + 0.5 - longitude/360 - eot/1440
+		if rising {
+			jd = noon - h
+		} else {
+			jd = noon + h
+		}
+	}
+
+	return jd, 0
+}
+
+// SunTimes t所在日期在纬度latitude、经度longitude(度,北纬、东经为正)、海拔elevation(米)处的日出日落及晨昏蒙影时刻
+//
+// 日期和返回的时间都使用t的时区
+func SunTimes(t time.Time, latitude, longitude, elevation float64) *SunItem {
+	loc := t.Location()
+	year, month, day := t.Date()
+	noon := timeToJd(time.Date(year, month, day, 12, 0, 0, 0, loc))
+
+	latitude = math.Max(-90, math.Min(90, latitude))
+	if latitude == 90 || latitude == -90 {
+		// 避免除以0,极点处的结果与纬度89.9999度无异
+		latitude = math.Copysign(89.9999, latitude)
+	}
+
+	toTime := func(jd float64) *time.Time {
+		tt := JdToTime(jd, loc)
+		return &tt
+	}
+
+	si := new(SunItem)
+	si.SolarNoon = toTime(solarNoonJd(noon, longitude))
+
+	// 海拔高处地平线下沉,日出提前日落推迟
+	h0 := cSunriseAltitude
+	if elevation > 0 {
+		h0 -= 2.076 * math.Sqrt(elevation) / 60
+	}
+
+	events := []struct {
+		altitude  float64
+		rise, set **time.Time
+		markPolar bool
+	}{
+		{h0, &si.Sunrise, &si.Sunset, true},
+		{cCivilTwilight, &si.CivilDawn, &si.CivilDusk, false},
+		{cNauticalTwilight, &si.NauticalDawn, &si.NauticalDusk, false},
+		{cAstronomicalTwilight, &si.AstronomicalDawn, &si.AstronomicalDusk, false},
+	}
+
+	for _, ev := range events {
+		if jd, polar := sunAltitudeJd(noon, latitude, longitude, ev.altitude, true); polar == 0 {
+			*ev.rise = toTime(jd)
+		} else if ev.markPolar {
+			si.PolarDay, si.PolarNight = polar > 0, polar < 0
+		}
+		if jd, polar := sunAltitudeJd(noon, latitude, longitude, ev.altitude, false); polar == 0 {
+			*ev.set = toTime(jd)
+		}
+	}
+
+	return si
+}
+
+// (*Calendar) SunTimes t所在日期在配置的地理位置处的日出日落及晨昏蒙影时刻
+//
+// 地理位置使用CalendarConfig的Latitude、Longitude、Elevation,返回的时间使用日历的时区
+func (c *Calendar) SunTimes(t time.Time) *SunItem {
+	return SunTimes(t.In(c.loc), c.config.Latitude, c.config.Longitude, c.config.Elevation)
+}
+
+// TrueSolarTime 时间t在经度longitude(度,东经为正)处的真太阳时
+//
+// 用经度与t所在时区标准子午线的差(每度4分钟)及时差修正t的钟面时间,返回的时间使用t的时区,
+// 如东八区的乌鲁木齐(东经87.6度)北京时间12:00的真太阳时约为09:50
+func TrueSolarTime(t time.Time, longitude float64) time.Time {
+	_, offset := t.Zone()
+
+	// 平太阳时与钟面时间的差
+	d := longitude*240 - float64(offset)
+
+	// 加上时差得到真太阳时
+	d += equationOfTime(timeToJd(t)) * 60
+
+	return t.Add(time.Duration(math.Round(d)) * time.Second)
+}
+
+// (*Calendar) TrueSolarTime 时间t在CalendarConfig.Longitude处的真太阳时,返回的时间使用日历的时区
+//
+// 需设置CalendarConfig.LocationSet,未设置时返回t的钟面时间
+func (c *Calendar) TrueSolarTime(t time.Time) time.Time {
+	if !c.config.LocationSet {
+		return t.In(c.loc)
+	}
+	return TrueSolarTime(t.In(c.loc), c.config.Longitude)
+}
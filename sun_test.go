@@ -0,0 +1,165 @@
+package gocalendar
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSunTimes(t *testing.T) {
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+
+	// 北京 2021年夏至
+	si := SunTimes(time.Date(2021, 6, 21, 0, 0, 0, 0, loc), 39.9042, 116.4074, 0)
+	want := map[string]time.Time{
+		"sunrise": time.Date(2021, 6, 21, 4, 45, 0, 0, loc),
+		"noon":    time.Date(2021, 6, 21, 12, 16, 0, 0, loc),
+		"sunset":  time.Date(2021, 6, 21, 19, 46, 0, 0, loc),
+		"cdawn":   time.Date(2021, 6, 21, 4, 12, 0, 0, loc),
+		"cdusk":   time.Date(2021, 6, 21, 20, 19, 0, 0, loc),
+	}
+	got := map[string]*time.Time{
+		"sunrise": si.Sunrise,
+		"noon":    si.SolarNoon,
+		"sunset":  si.Sunset,
+		"cdawn":   si.CivilDawn,
+		"cdusk":   si.CivilDusk,
+	}
+	for k, w := range want {
+		if got[k] == nil || math.Abs(got[k].Sub(w).Minutes()) > 2 {
+			t.Error(k, got[k], w)
+		}
+	}
+	if si.PolarDay || si.PolarNight {
+		t.Error(si)
+	}
+}
+
+func TestSunTimes_FarFromMeridian(t *testing.T) {
+	// 时区与经度相差较大时,当地日期与UT日期不同,事件仍应在所求的当地日期
+	tests := []struct {
+		zone     string
+		lat, lon float64
+		noon     int // 上中天的当地时刻(分钟)
+	}{
+		{"Pacific/Kiritimati", 1.87, -157.43, 12*60 + 37},  // 东十四区
+		{"Pacific/Pago_Pago", -14.28, -170.70, 12*60 + 30}, // 西十一区
+		{"Pacific/Apia", -13.83, -171.76, 13*60 + 34},      // 东十三区,2021年3月为夏令时东十四区
+	}
+	for _, tt := range tests {
+		loc, _ := time.LoadLocation(tt.zone)
+		for _, d := range []int{20, 21} {
+			si := SunTimes(time.Date(2021, 3, d, 0, 0, 0, 0, loc), tt.lat, tt.lon, 0)
+			ok := si.Sunrise != nil && si.Sunset != nil && si.SolarNoon != nil && si.CivilDawn != nil && si.CivilDusk != nil
+			for _, e := range []*time.Time{si.Sunrise, si.SolarNoon, si.Sunset, si.CivilDawn, si.CivilDusk} {
+				ok = ok && e.Day() == d
+			}
+			if ok && math.Abs(float64(si.SolarNoon.Hour()*60+si.SolarNoon.Minute()-tt.noon)) <= 2 {
+				t.Log("passed")
+			} else {
+				t.Error(tt.zone, d, si.Sunrise, si.SolarNoon, si.Sunset)
+			}
+		}
+	}
+}
+
+func TestSunTimes_Polar(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Oslo")
+
+	// 特罗姆瑟
+	si := SunTimes(time.Date(2021, 6, 21, 0, 0, 0, 0, loc), 69.6496, 18.956, 0)
+	if si.PolarDay && si.Sunrise == nil && si.Sunset == nil && si.SolarNoon != nil && si.CivilDawn == nil {
+		t.Log("passed")
+	} else {
+		t.Error(si)
+	}
+
+	si = SunTimes(time.Date(2021, 12, 21, 0, 0, 0, 0, loc), 69.6496, 18.956, 0)
+	if si.PolarNight && si.Sunrise == nil && si.CivilDawn != nil && si.CivilDusk != nil {
+		t.Log("passed")
+	} else {
+		t.Error(si)
+	}
+}
+
+func TestCalendar_SunItem(t *testing.T) {
+	c := NewCalendar(CalendarConfig{Grid: GridDay, TimeZoneName: "Asia/Shanghai", Sun: true, Latitude: 39.9042, Longitude: 116.4074, Elevation: 1000})
+	items := c.GenerateWithDate(2021, 6, 21)
+	si := items[0].Sun
+
+	// 海拔越高日出越早
+	sr := SunTimes(*items[0].Time, 39.9042, 116.4074, 0).Sunrise
+	if si != nil && si.Sunrise.Before(*sr) && si.Sunrise.Location() == c.loc {
+		t.Log("passed")
+	} else {
+		t.Error(si)
+	}
+}
+
+func TestTrueSolarTime(t *testing.T) {
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+
+	// 乌鲁木齐
+	st := TrueSolarTime(time.Date(2021, 5, 6, 12, 0, 0, 0, loc), 87.6)
+	if st.Location() == loc && st.Hour() == 9 && st.Minute() >= 52 && st.Minute() <= 55 {
+		t.Log("passed")
+	} else {
+		t.Error(st)
+	}
+}
+
+func TestCalendar_TrueSolarTimeGZ(t *testing.T) {
+	c := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai", NightZiHour: true, TrueSolarTime: true, Longitude: 87.6, LocationSet: true})
+	rt := time.Date(2021, 5, 6, 23, 50, 0, 0, c.loc)
+
+	// 真太阳时约21:44,不是夜子时,日柱为当日甲寅
+	gz := c.ChineseSexagenaryCycle(rt)
+	if gz.Day.HSI == 0 && gz.Day.EBI == 2 && gz.Hour.EBI == 11 && gz.SolarTime != nil && gz.SolarTime.Hour() == 21 {
+		t.Log("passed")
+	} else {
+		t.Error(gz.Day, gz.Hour, gz.SolarTime)
+	}
+
+	// 真太阳时跨过0时,日柱为前一日
+	gz = c.ChineseSexagenaryCycle(time.Date(2021, 5, 7, 1, 0, 0, 0, c.loc))
+	if gz.Day.HSI == 0 && gz.Day.EBI == 2 && gz.Hour.EBI == 11 {
+		t.Log("passed")
+	} else {
+		t.Error(gz.Day, gz.Hour, gz.SolarTime)
+	}
+
+	c = NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai", NightZiHour: true})
+	if gz := c.ChineseSexagenaryCycle(rt); gz.SolarTime == nil && gz.Hour.EBI == 0 {
+		t.Log("passed")
+	} else {
+		t.Error(gz.Hour, gz.SolarTime)
+	}
+}
+
+// 未设置LocationSet时不按经度0度的真太阳时计算
+func TestCalendar_TrueSolarTimeNoLocation(t *testing.T) {
+	c := NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai", NightZiHour: true, TrueSolarTime: true})
+	rt := time.Date(2021, 5, 6, 23, 50, 0, 0, c.loc)
+
+	if gz := c.ChineseSexagenaryCycle(rt); gz.SolarTime == nil && gz.Hour.EBI == 0 && c.TrueSolarTime(rt).Equal(rt) {
+		t.Log("passed")
+	} else {
+		t.Error(gz.Hour, gz.SolarTime, c.TrueSolarTime(rt))
+	}
+
+	if _, err := c.ChineseSexagenaryCycleE(context.Background(), rt); errors.Is(err, ErrNoLocation) {
+		t.Log("passed")
+	} else {
+		t.Error(err)
+	}
+
+	// 经度0度需设置LocationSet
+	c = NewCalendar(CalendarConfig{TimeZoneName: "Asia/Shanghai", TrueSolarTime: true, LocationSet: true})
+	if gz, err := c.ChineseSexagenaryCycleE(context.Background(), rt); err == nil && gz.SolarTime != nil && gz.SolarTime.Hour() == 15 {
+		t.Log("passed")
+	} else {
+		t.Error(gz.SolarTime, err)
+	}
+}